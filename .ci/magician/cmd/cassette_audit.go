@@ -0,0 +1,395 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/test-reader/reader"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	auditServicesDir  string
+	auditDeleteOrphan bool
+	auditDryRun       bool
+	auditSizeFactor   float64
+	auditReplayLogs   string
+)
+
+// vcrDriftMessage is logged by the provider's VCR matcher when a request sent while
+// replaying matches no recorded interaction, followed by the closest interaction and
+// how it differs.
+const vcrDriftMessage = "VCR found no recorded interaction for request"
+
+// secretPatterns are matched against every recorded request and response. A match means
+// the cassette contains a value that should have been scrubbed before it was uploaded.
+var secretPatterns = map[string]*regexp.Regexp{
+	"oauth access token":  regexp.MustCompile(`ya29\.[0-9A-Za-z_\-]{20,}`),
+	"oauth refresh token": regexp.MustCompile(`1//[0-9A-Za-z_\-]{30,}`),
+	"api key":             regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`),
+	"private key":         regexp.MustCompile(`-----BEGIN (RSA |EC )?PRIVATE KEY-----`),
+	"private key id":      regexp.MustCompile(`"private_key_id"\s*:\s*"[0-9a-f]{40}"`),
+	"password":            regexp.MustCompile(`"(password|rootPassword|adminPassword)"\s*:\s*"[^"]+"`),
+}
+
+// secretHeaders are request headers whose recorded value must be empty or redacted.
+var secretHeaders = []string{"Authorization", "X-Goog-Api-Key", "Proxy-Authorization"}
+
+type cassetteAuditCassette struct {
+	Interactions []struct {
+		Request struct {
+			Body    string              `yaml:"body"`
+			Headers map[string][]string `yaml:"headers"`
+			URL     string              `yaml:"url"`
+			Method  string              `yaml:"method"`
+		} `yaml:"request"`
+		Response struct {
+			Body    string              `yaml:"body"`
+			Headers map[string][]string `yaml:"headers"`
+		} `yaml:"response"`
+	} `yaml:"interactions"`
+}
+
+type cassetteFinding struct {
+	Cassette    string
+	Interaction int
+	Kind        string
+	Detail      string
+}
+
+type cassetteAuditReport struct {
+	Total    int
+	Orphans  []string            // cassette names with no matching test
+	Subtests []string            // cassette names that may have been recorded by a subtest
+	Findings []cassetteFinding   // credentials or PII found in interactions
+	Outliers map[string]int64    // cassette name to size in bytes
+	Drift    map[string][]string // cassette name to requests that no longer match it
+	Errors   map[string]error    // cassette name to parse error
+}
+
+var cassetteAuditCmd = &cobra.Command{
+	Use:   "cassette-audit CASSETTE_DIR",
+	Short: "Audit a directory of VCR cassettes",
+	Long: `This command walks a directory of VCR cassettes and reports problems with them.
+
+	The command expects the following as arguments:
+	1. Path to a directory of downloaded cassettes (e.g. gsutil cp gs://ci-vcr-cassettes/beta/fixtures)
+
+	It then performs the following operations:
+	1. Cross-reference cassettes with tests read from --services-dir (if provided) to find orphans.
+	2. Scan every interaction for un-scrubbed credentials and secrets.
+	3. Report cassettes that are much larger than the median cassette.
+	4. Report cassettes whose recorded requests no longer match what the provider sends, read
+	   from the per-test logs of a REPLAYING run in --replay-logs (if provided).
+	5. Delete orphaned cassettes and seeds if --delete-orphans is set and --dry-run=false.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var testNames []string
+		if auditServicesDir != "" {
+			tests, errs := reader.ReadAllTests(auditServicesDir)
+			if len(errs) > 0 {
+				// Tests that couldn't be read would make their cassettes look orphaned
+				for path, err := range errs {
+					fmt.Printf("error reading path: %s, err: %v\n", path, err)
+				}
+				return fmt.Errorf("error reading tests from %s: %d files or tests could not be read", auditServicesDir, len(errs))
+			}
+			for _, test := range tests {
+				testNames = append(testNames, test.Name)
+			}
+		}
+		report, err := execCassetteAudit(args[0], testNames, auditSizeFactor)
+		if err != nil {
+			return err
+		}
+		if auditReplayLogs != "" {
+			if report.Drift, err = readCassetteDrift(auditReplayLogs); err != nil {
+				return err
+			}
+		}
+		printCassetteAuditReport(report)
+		if auditDeleteOrphan {
+			return deleteOrphanedCassettes(args[0], report.Orphans, auditDryRun)
+		}
+		return nil
+	},
+}
+
+// execCassetteAudit audits every cassette in cassetteDir. If testNames is empty, orphan
+// detection is skipped.
+func execCassetteAudit(cassetteDir string, testNames []string, sizeFactor float64) (*cassetteAuditReport, error) {
+	entries, err := os.ReadDir(cassetteDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cassette dir: %w", err)
+	}
+	report := &cassetteAuditReport{
+		Outliers: make(map[string]int64),
+		Errors:   make(map[string]error),
+	}
+	sizes := make(map[string]int64)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".yaml")
+		report.Total++
+		info, err := entry.Info()
+		if err != nil {
+			report.Errors[name] = err
+			continue
+		}
+		sizes[name] = info.Size()
+
+		if len(testNames) > 0 {
+			switch cassetteTestMatch(name, testNames) {
+			case cassetteNoTest:
+				report.Orphans = append(report.Orphans, name)
+			case cassetteSubtest:
+				report.Subtests = append(report.Subtests, name)
+			}
+		}
+
+		data, err := os.ReadFile(filepath.Join(cassetteDir, entry.Name()))
+		if err != nil {
+			report.Errors[name] = err
+			continue
+		}
+		findings, err := scanCassette(name, data)
+		if err != nil {
+			report.Errors[name] = err
+			continue
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+	report.Outliers = sizeOutliers(sizes, sizeFactor)
+	sort.Strings(report.Orphans)
+	sort.Strings(report.Subtests)
+	return report, nil
+}
+
+type cassetteMatch int
+
+const (
+	cassetteNoTest cassetteMatch = iota
+	cassetteTest
+	cassetteSubtest
+)
+
+// cassetteTestMatch reports whether the cassette was recorded by one of the given tests. Subtests
+// are recorded as TestName_SubtestName, since '/' is replaced by '_' in the cassette file name,
+// which can't be told apart from a removed test named TestName_Suffix. Those cassettes are
+// reported as possible subtests rather than orphans, so they're never deleted.
+func cassetteTestMatch(cassette string, testNames []string) cassetteMatch {
+	match := cassetteNoTest
+	for _, test := range testNames {
+		if cassette == test {
+			return cassetteTest
+		}
+		if strings.HasPrefix(cassette, test+"_") {
+			match = cassetteSubtest
+		}
+	}
+	return match
+}
+
+// readCassetteDrift reads the per-test logs of a REPLAYING run, named TestName.log, and returns
+// the requests that matched no recorded interaction in each test's cassette.
+func readCassetteDrift(logDir string) (map[string][]string, error) {
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replay log dir: %w", err)
+	}
+	drift := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".log" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(logDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading replay log: %w", err)
+		}
+		name := cassetteNameForTest(strings.TrimSuffix(entry.Name(), ".log"))
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if i := strings.Index(line, vcrDriftMessage); i >= 0 {
+				detail := strings.TrimLeft(line[i+len(vcrDriftMessage):], ",: ")
+				drift[name] = append(drift[name], detail)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading replay log %s: %w", entry.Name(), err)
+		}
+	}
+	return drift, nil
+}
+
+// cassetteNameForTest returns the name of the cassette recorded by a test, as written by the
+// provider's VCR setup.
+func cassetteNameForTest(testName string) string {
+	return strings.ReplaceAll(testName, "/", "_")
+}
+
+func scanCassette(name string, data []byte) ([]cassetteFinding, error) {
+	var c cassetteAuditCassette
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error parsing cassette: %w", err)
+	}
+	var findings []cassetteFinding
+	for i, interaction := range c.Interactions {
+		for _, header := range secretHeaders {
+			for k, values := range interaction.Request.Headers {
+				if !strings.EqualFold(k, header) {
+					continue
+				}
+				for _, v := range values {
+					if v != "" && !strings.Contains(v, "REDACTED") {
+						findings = append(findings, cassetteFinding{name, i, "header", k})
+					}
+				}
+			}
+		}
+		for _, text := range []string{interaction.Request.URL, interaction.Request.Body, interaction.Response.Body} {
+			for kind, pattern := range secretPatterns {
+				if pattern.MatchString(text) {
+					findings = append(findings, cassetteFinding{name, i, kind, interaction.Request.Method + " " + interaction.Request.URL})
+				}
+			}
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Interaction != findings[j].Interaction {
+			return findings[i].Interaction < findings[j].Interaction
+		}
+		return findings[i].Kind < findings[j].Kind
+	})
+	return findings, nil
+}
+
+// sizeOutliers returns the cassettes larger than factor times the median cassette size.
+func sizeOutliers(sizes map[string]int64, factor float64) map[string]int64 {
+	outliers := make(map[string]int64)
+	if len(sizes) == 0 || factor <= 0 {
+		return outliers
+	}
+	sorted := make([]int64, 0, len(sizes))
+	for _, size := range sizes {
+		sorted = append(sorted, size)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	median := sorted[len(sorted)/2]
+	for name, size := range sizes {
+		if float64(size) > float64(median)*factor {
+			outliers[name] = size
+		}
+	}
+	return outliers
+}
+
+func printCassetteAuditReport(report *cassetteAuditReport) {
+	fmt.Printf("Audited %d cassettes\n", report.Total)
+
+	fmt.Printf("\n%d orphaned cassettes:\n", len(report.Orphans))
+	for _, name := range report.Orphans {
+		fmt.Printf("  %s\n", name)
+	}
+
+	if len(report.Subtests) > 0 {
+		fmt.Printf("\n%d cassettes that may belong to subtests (not deleted):\n", len(report.Subtests))
+		for _, name := range report.Subtests {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+
+	fmt.Printf("\n%d possible secrets:\n", len(report.Findings))
+	for _, f := range report.Findings {
+		fmt.Printf("  %s[%d]: %s (%s)\n", f.Cassette, f.Interaction, f.Kind, f.Detail)
+	}
+
+	names := make([]string, 0, len(report.Outliers))
+	for name := range report.Outliers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("\n%d size outliers:\n", len(names))
+	for _, name := range names {
+		fmt.Printf("  %s: %d bytes\n", name, report.Outliers[name])
+	}
+
+	if report.Drift != nil {
+		drifted := make([]string, 0, len(report.Drift))
+		for name := range report.Drift {
+			drifted = append(drifted, name)
+		}
+		sort.Strings(drifted)
+		fmt.Printf("\n%d cassettes with requests that no longer match:\n", len(drifted))
+		for _, name := range drifted {
+			fmt.Printf("  %s:\n", name)
+			for _, detail := range report.Drift[name] {
+				fmt.Printf("    %s\n", detail)
+			}
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Printf("\n%d cassettes could not be read:\n", len(report.Errors))
+		for name, err := range report.Errors {
+			fmt.Printf("  %s: %v\n", name, err)
+		}
+	}
+}
+
+// deleteOrphanedCassettes removes the cassette and seed file for each orphan. In dry-run
+// mode it only prints the files that would be removed.
+func deleteOrphanedCassettes(cassetteDir string, orphans []string, dryRun bool) error {
+	for _, name := range orphans {
+		for _, ext := range []string{".yaml", ".seed"} {
+			path := filepath.Join(cassetteDir, name+ext)
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				continue
+			}
+			if dryRun {
+				fmt.Printf("DRY RUN: would delete %s\n", path)
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("error deleting %s: %w", path, err)
+			}
+			fmt.Printf("Deleted %s\n", path)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(cassetteAuditCmd)
+	cassetteAuditCmd.Flags().StringVar(&auditServicesDir, "services-dir", "", "Provider services directory used to find tests for orphan detection")
+	cassetteAuditCmd.Flags().BoolVar(&auditDeleteOrphan, "delete-orphans", false, "Delete cassettes that have no matching test")
+	cassetteAuditCmd.Flags().BoolVar(&auditDryRun, "dry-run", true, "Only print the cassettes that would be deleted")
+	cassetteAuditCmd.Flags().Float64Var(&auditSizeFactor, "size-factor", 10, "Report cassettes larger than this multiple of the median cassette size")
+	cassetteAuditCmd.Flags().StringVar(&auditReplayLogs, "replay-logs", "", "Directory of per-test logs from a REPLAYING run (e.g. testlogs/replaying/beta) used to find cassettes that no longer match")
+}
@@ -0,0 +1,225 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const cleanCassette = `---
+version: 1
+interactions:
+- request:
+    body: '{"name":"foo"}'
+    headers:
+      Content-Type:
+      - application/json
+    url: https://compute.googleapis.com/compute/v1/projects/p/global/networks
+    method: POST
+  response:
+    body: '{"name":"operation-1"}'
+    code: 200
+`
+
+const leakyCassette = `---
+version: 1
+interactions:
+- request:
+    body: ""
+    headers:
+      Authorization:
+      - Bearer ya29.abcdefghijklmnopqrstuvwxyz0123456789
+    url: https://sqladmin.googleapis.com/v1/projects/p/instances/i/users
+    method: GET
+  response:
+    body: '{"items":[{"name":"root","password":"hunter2"}]}'
+    code: 200
+`
+
+func writeCassettes(t *testing.T, cassettes map[string]string) string {
+	dir := t.TempDir()
+	for name, contents := range cassettes {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExecCassetteAudit(t *testing.T) {
+	dir := writeCassettes(t, map[string]string{
+		"TestAccComputeNetwork_basic.yaml":            cleanCassette,
+		"TestAccComputeNetwork_basic.seed":            "1",
+		"TestAccLoggingFolderExclusion_folder_a.yaml": cleanCassette,
+		"TestAccSqlUser_removed.yaml":                 leakyCassette,
+		"TestAccSqlUser_removed.seed":                 "2",
+		"TestAccComputeInstance_huge.yaml":            cleanCassette + strings.Repeat("#", 20*len(cleanCassette)),
+		"TestAccComputeNetwork_basic.yaml.bak":        "ignored",
+	})
+	testNames := []string{"TestAccComputeNetwork_basic", "TestAccLoggingFolderExclusion", "TestAccComputeInstance_huge"}
+
+	report, err := execCassetteAudit(dir, testNames, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Total != 4 {
+		t.Errorf("wrong number of cassettes audited: got %d, expected 4", report.Total)
+	}
+	if expected := []string{"TestAccSqlUser_removed"}; !reflect.DeepEqual(report.Orphans, expected) {
+		t.Errorf("wrong orphans: got %v, expected %v", report.Orphans, expected)
+	}
+	if expected := []string{"TestAccLoggingFolderExclusion_folder_a"}; !reflect.DeepEqual(report.Subtests, expected) {
+		t.Errorf("wrong subtests: got %v, expected %v", report.Subtests, expected)
+	}
+	var kinds []string
+	for _, f := range report.Findings {
+		if f.Cassette != "TestAccSqlUser_removed" {
+			t.Errorf("unexpected finding in %s: %v", f.Cassette, f)
+		}
+		kinds = append(kinds, f.Kind)
+	}
+	if expected := []string{"header", "password"}; !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("wrong findings: got %v, expected %v", kinds, expected)
+	}
+	if _, ok := report.Outliers["TestAccComputeInstance_huge"]; !ok || len(report.Outliers) != 1 {
+		t.Errorf("wrong outliers: got %v, expected only TestAccComputeInstance_huge", report.Outliers)
+	}
+}
+
+func TestExecCassetteAuditWithoutTests(t *testing.T) {
+	dir := writeCassettes(t, map[string]string{
+		"TestAccSqlUser_removed.yaml": cleanCassette,
+	})
+	report, err := execCassetteAudit(dir, nil, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Orphans) != 0 {
+		t.Errorf("expected no orphans without tests, got %v", report.Orphans)
+	}
+}
+
+func TestCassetteAuditCmdUnreadableTests(t *testing.T) {
+	dir := writeCassettes(t, map[string]string{
+		"TestAccSqlUser_basic.yaml": cleanCassette,
+	})
+	servicesDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(servicesDir, "sql"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, contents := range map[string]string{
+		"resource_sql_database_test.go": "package sql\n\nfunc TestAccSqlDatabase_basic(t *testing.T) {}\n",
+		"resource_sql_user_test.go":     "package sql\n\nfunc TestAccSqlUser_basic(t *testing.T) {",
+	} {
+		if err := os.WriteFile(filepath.Join(servicesDir, "sql", name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	auditServicesDir, auditDeleteOrphan, auditDryRun = servicesDir, true, false
+	defer func() {
+		auditServicesDir, auditDeleteOrphan, auditDryRun = "", false, true
+	}()
+
+	if err := cassetteAuditCmd.RunE(cassetteAuditCmd, []string{dir}); err == nil {
+		t.Error("expected an error reading tests, got none")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "TestAccSqlUser_basic.yaml")); err != nil {
+		t.Errorf("cassette of an unreadable test was deleted: %v", err)
+	}
+}
+
+func TestDeleteOrphanedCassettes(t *testing.T) {
+	dir := writeCassettes(t, map[string]string{
+		"TestAccSqlUser_removed.yaml": cleanCassette,
+		"TestAccSqlUser_removed.seed": "2",
+	})
+	orphans := []string{"TestAccSqlUser_removed"}
+
+	if err := deleteOrphanedCassettes(dir, orphans, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "TestAccSqlUser_removed.yaml")); err != nil {
+		t.Errorf("dry run deleted cassette: %v", err)
+	}
+
+	if err := deleteOrphanedCassettes(dir, orphans, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ext := range []string{".yaml", ".seed"} {
+		if _, err := os.Stat(filepath.Join(dir, "TestAccSqlUser_removed"+ext)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be deleted, got %v", ext, err)
+		}
+	}
+}
+
+func TestCassetteTestMatch(t *testing.T) {
+	cases := map[string]struct {
+		cassette  string
+		testNames []string
+		expected  cassetteMatch
+	}{
+		"exact": {
+			cassette:  "TestAccFoo_bar",
+			testNames: []string{"TestAccFoo", "TestAccFoo_bar"},
+			expected:  cassetteTest,
+		},
+		"longer test name": {
+			cassette:  "TestAccFoo",
+			testNames: []string{"TestAccFoo_bar"},
+			expected:  cassetteNoTest,
+		},
+		"possible subtest": {
+			cassette:  "TestAccFoo_bar",
+			testNames: []string{"TestAccFoo"},
+			expected:  cassetteSubtest,
+		},
+		"no test": {
+			cassette:  "TestAccFoobar",
+			testNames: []string{"TestAccFoo"},
+			expected:  cassetteNoTest,
+		},
+	}
+	for tn, tc := range cases {
+		if got := cassetteTestMatch(tc.cassette, tc.testNames); got != tc.expected {
+			t.Errorf("%s: got %v, expected %v", tn, got, tc.expected)
+		}
+	}
+}
+
+func TestReadCassetteDrift(t *testing.T) {
+	dir := writeCassettes(t, map[string]string{
+		"TestAccPubsubTopic_update.log": `2024-01-02T00:00:00.000Z [DEBUG] provider: sending request
+2024-01-02T00:00:00.000Z [WARN]  provider.terraform-provider-google: VCR found no recorded interaction for request: closest_method=PATCH diff=[".labels.created: 2024-01-02 != 2023-12-31"] method=PATCH mismatch=body
+`,
+		"TestAccPubsubTopic_basic.log": "2024-01-02T00:00:00.000Z [DEBUG] provider: sending request\n",
+		"replaying_test.txt":           "VCR found no recorded interaction for request",
+	})
+
+	drift, err := readCassetteDrift(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string][]string{
+		"TestAccPubsubTopic_update": {`closest_method=PATCH diff=[".labels.created: 2024-01-02 != 2023-12-31"] method=PATCH mismatch=body`},
+	}
+	if !reflect.DeepEqual(drift, expected) {
+		t.Errorf("wrong drift: got %v, expected %v", drift, expected)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"magician/github"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// explainAssignmentCmd represents the explainAssignment command
+var explainAssignmentCmd = &cobra.Command{
+	Use:   "explain-assignment PR_NUMBER",
+	Short: "Explains how a primary reviewer would be chosen for a PR",
+	Long: `This command is a dry run of primary reviewer assignment. It does not request reviews or post comments.
+
+	The command expects the following PR details as arguments:
+	1. PR_NUMBER
+
+	It then prints every available core reviewer with their score, based on:
+	1. Expertise in the service/* labels on the PR.
+	2. The number of open PRs awaiting their review or that they have already reviewed.
+	3. Whether it is currently within their working hours.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		githubToken, ok := os.LookupEnv("GITHUB_TOKEN")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+		}
+		gh := github.NewClient(githubToken)
+		return execExplainAssignment(args[0], gh, os.Stdout)
+	},
+}
+
+func execExplainAssignment(prNumber string, gh GithubClient, out io.Writer) error {
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return err
+	}
+	inputs, err := assignmentInputs(pullRequest, gh)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Service labels: %v\n", inputs.ServiceLabels)
+	scores := github.ScoreReviewers(inputs)
+	if len(scores) == 0 {
+		fmt.Fprintln(out, "No reviewers are available")
+		return nil
+	}
+	fmt.Fprintln(out, "Candidates:")
+	for _, score := range scores {
+		fmt.Fprintf(out, "  %s\n", score)
+	}
+	tied := []string{scores[0].Reviewer}
+	for _, score := range scores[1:] {
+		if score.Score == scores[0].Score {
+			tied = append(tied, score.Reviewer)
+		}
+	}
+	if len(tied) > 1 {
+		fmt.Fprintf(out, "A primary reviewer would be chosen at random from %v\n", tied)
+	} else {
+		fmt.Fprintf(out, "%s would be chosen as primary reviewer\n", tied[0])
+	}
+	return nil
+}
+
+// assignmentInputs collects what is known about a pull request to choose its primary reviewer.
+func assignmentInputs(pullRequest github.PullRequest, gh GithubClient) (github.AssignmentInputs, error) {
+	openPullRequests, err := gh.GetAllPullRequests("open", "main", "created", "desc")
+	if err != nil {
+		return github.AssignmentInputs{}, err
+	}
+	previousReviewers := make(map[int][]github.User, len(openPullRequests))
+	for _, pr := range openPullRequests {
+		reviewers, err := gh.GetPullRequestPreviousReviewers(strconv.Itoa(pr.Number))
+		if err != nil {
+			return github.AssignmentInputs{}, err
+		}
+		previousReviewers[pr.Number] = reviewers
+	}
+	return github.AssignmentInputs{
+		Now:           time.Now(),
+		ServiceLabels: github.ServiceLabels(pullRequest),
+		OpenReviews:   github.OpenReviewCounts(openPullRequests, previousReviewers),
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(explainAssignmentCmd)
+}
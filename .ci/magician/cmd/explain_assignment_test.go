@@ -0,0 +1,53 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package cmd
+
+import (
+	"magician/github"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecExplainAssignment(t *testing.T) {
+	availableReviewers := github.AvailableReviewers()
+	gh := &mockGithub{
+		pullRequest: github.PullRequest{
+			User:               github.User{Login: "author"},
+			Labels:             []github.Label{{Name: "service/compute-instances"}, {Name: "size/xs"}},
+			RequestedReviewers: []github.User{{Login: availableReviewers[0]}},
+		},
+		calledMethods: make(map[string][][]any),
+	}
+
+	out := new(strings.Builder)
+	if err := execExplainAssignment("1", gh, out); err != nil {
+		t.Fatalf("execExplainAssignment() returned error: %v", err)
+	}
+
+	assert.Contains(t, out.String(), "Service labels: [service/compute-instances]")
+	for _, reviewer := range availableReviewers {
+		assert.Contains(t, out.String(), reviewer+": score")
+	}
+	assert.Regexp(t, availableReviewers[0]+`: score -?\d+ \(open reviews: 1,`, out.String())
+	assert.NotEmpty(t, gh.calledMethods["GetAllPullRequests"], "open reviews should be counted across every page")
+	assert.NotEmpty(t, gh.calledMethods["GetPullRequestPreviousReviewers"], "reviews already given on open pull requests should be counted")
+	for _, method := range []string{"RequestPullRequestReviewers", "PostComment", "UpdateComment"} {
+		assert.Empty(t, gh.calledMethods[method], "dry run should not call %s", method)
+	}
+}
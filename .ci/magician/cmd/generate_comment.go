@@ -44,6 +44,9 @@ var (
 	diffComment string
 )
 
+// diffCommentHeader starts every diff comment, so other commands can find it.
+const diffCommentHeader = "Hi there, I'm the Modular magician. I've detected the following information about your changes:"
+
 type Diff struct {
 	Title     string
 	Repo      string
@@ -336,7 +339,15 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 
 	// Compute service labels based on affected resources
 	uniqueServiceLabels := map[string]struct{}{}
-	regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+	var metadata []labeler.ResourceMetadata
+	if tpgbRepo.Cloned {
+		// Resources that aren't listed in enrolled_teams.yml are labelled based on their metadata.
+		metadata, err = labeler.LoadResourceMetadata(tpgbRepo.Path)
+		if err != nil {
+			fmt.Println("error loading resource metadata: ", err)
+		}
+	}
+	regexpLabels, err := labeler.BuildLabels(labeler.EnrolledTeamsYaml, metadata)
 	if err != nil {
 		fmt.Println("error building regexp labels: ", err)
 		errors["Other"] = append(errors["Other"], "Failed to parse service label mapping")
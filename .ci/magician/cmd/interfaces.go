@@ -22,6 +22,7 @@ import (
 type GithubClient interface {
 	GetPullRequest(prNumber string) (github.PullRequest, error)
 	GetPullRequests(state, base, sort, direction string) ([]github.PullRequest, error)
+	GetAllPullRequests(state, base, sort, direction string) ([]github.PullRequest, error)
 	GetPullRequestRequestedReviewers(prNumber string) ([]github.User, error)
 	GetPullRequestPreviousReviewers(prNumber string) ([]github.User, error)
 	GetPullRequestComments(prNumber string) ([]github.PullRequestComment, error)
@@ -41,6 +41,11 @@ func (m *mockGithub) GetPullRequests(state, base, sort, direction string) ([]git
 	return []github.PullRequest{m.pullRequest}, nil
 }
 
+func (m *mockGithub) GetAllPullRequests(state, base, sort, direction string) ([]github.PullRequest, error) {
+	m.calledMethods["GetAllPullRequests"] = append(m.calledMethods["GetAllPullRequests"], []any{state, base, sort, direction})
+	return []github.PullRequest{m.pullRequest}, nil
+}
+
 func (m *mockGithub) GetUserType(user string) github.UserType {
 	m.calledMethods["GetUserType"] = append(m.calledMethods["GetUserType"], []any{user})
 	return m.userType
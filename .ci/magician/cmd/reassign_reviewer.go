@@ -27,7 +27,7 @@ import (
 // reassignReviewerCmd represents the reassignReviewer command
 var reassignReviewerCmd = &cobra.Command{
 	Use:   "reassign-reviewer PR_NUMBER [REVIEWER]",
-	Short: "Reassigns primary reviewer to the given reviewer or a random reviewer if none given",
+	Short: "Reassigns primary reviewer to the given reviewer or the best available reviewer if none given",
 	Long: `This command reassigns reviewers when invoked via a comment on a pull request.
 
 	The command expects the following PR details as arguments:
@@ -72,7 +72,7 @@ func execReassignReviewer(prNumber, newPrimaryReviewer string, gh GithubClient)
 			return err
 		}
 	} else {
-		fmt.Println("Reassigning to random reviewer")
+		fmt.Println("Reassigning primary reviewer")
 		newPrimaryReviewer, err = updateReviewComment(prNumber, currentReviewer, newPrimaryReviewer, reviewerComment.ID, gh)
 		if err != nil {
 			return err
@@ -91,7 +91,11 @@ func execReassignReviewer(prNumber, newPrimaryReviewer string, gh GithubClient)
 
 func createReviewComment(prNumber, newPrimaryReviewer string, gh GithubClient) (string, error) {
 	if newPrimaryReviewer == "" {
-		newPrimaryReviewer = github.GetRandomReviewer()
+		var err error
+		newPrimaryReviewer, err = chooseReviewer(prNumber, "", gh)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	if newPrimaryReviewer == "" {
@@ -107,7 +111,11 @@ func createReviewComment(prNumber, newPrimaryReviewer string, gh GithubClient) (
 
 func updateReviewComment(prNumber, currentReviewer, newPrimaryReviewer string, reviewerCommentID int, gh GithubClient) (string, error) {
 	if newPrimaryReviewer == "" {
-		newPrimaryReviewer = github.GetNewRandomReviewer(currentReviewer)
+		var err error
+		newPrimaryReviewer, err = chooseReviewer(prNumber, currentReviewer, gh)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	if currentReviewer == newPrimaryReviewer {
@@ -121,6 +129,22 @@ func updateReviewComment(prNumber, currentReviewer, newPrimaryReviewer string, r
 	return newPrimaryReviewer, nil
 }
 
+// chooseReviewer returns the best available primary reviewer for the pull request other than the current one.
+func chooseReviewer(prNumber, currentReviewer string, gh GithubClient) (string, error) {
+	pullRequest, err := gh.GetPullRequest(prNumber)
+	if err != nil {
+		return "", err
+	}
+	inputs, err := assignmentInputs(pullRequest, gh)
+	if err != nil {
+		return "", err
+	}
+	if currentReviewer != "" {
+		inputs.Exclude = append(inputs.Exclude, currentReviewer)
+	}
+	return github.ChooseReviewer(inputs), nil
+}
+
 func init() {
 	rootCmd.AddCommand(reassignReviewerCmd)
 }
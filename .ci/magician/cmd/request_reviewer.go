@@ -36,7 +36,8 @@ var requestReviewerCmd = &cobra.Command{
 	1. Determines the author of the pull request
 	2. If the author is not a core contributor:
 			a. Identifies the initially requested reviewer and those who previously reviewed this PR.
-			b. Determines and requests reviewers based on the above.
+			b. Determines and requests reviewers based on the above. A new primary reviewer is chosen
+			   based on open review load, service expertise and working hours.
 			c. As appropriate, posts a welcome comment on the PR.
 	`,
 	Args: cobra.ExactArgs(1),
@@ -72,7 +73,12 @@ func execRequestReviewer(prNumber string, gh GithubClient) error {
 			return err
 		}
 
-		reviewersToRequest, newPrimaryReviewer := github.ChooseCoreReviewers(requestedReviewers, previousReviewers)
+		inputs, err := assignmentInputs(pullRequest, gh)
+		if err != nil {
+			return err
+		}
+
+		reviewersToRequest, newPrimaryReviewer := github.ChooseCoreReviewers(requestedReviewers, previousReviewers, inputs)
 
 		if len(reviewersToRequest) > 0 {
 			err = gh.RequestPullRequestReviewers(prNumber, reviewersToRequest)
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"magician/exec"
+	"magician/github"
+	"magician/provider"
+	"magician/source"
+	"magician/vcr"
+
+	_ "embed"
+)
+
+//go:embed templates/vcr/release_diff.tmpl
+var releaseDiffTmplText string
+
+// The release diff section is added to the diff comment between these markers, so a later
+// run replaces it.
+const (
+	releaseDiffStartMarker = "<!-- release-diff-tests -->"
+	releaseDiffEndMarker   = "<!-- /release-diff-tests -->"
+)
+
+type releaseDiff struct {
+	Services         []string
+	Result           vcr.Result
+	DiffTests        []string
+	OtherFailedTests []string
+	LogBucket        string
+	Version          string
+	Head             string
+	BuildID          string
+}
+
+var testReleaseDiffCmd = &cobra.Command{
+	Use:   "test-release-diff",
+	Short: "Run release diff tests for affected packages",
+	Long: `This command runs acceptance tests for services changed by a pull request in RELEASE_DIFF mode.
+Each test config is applied with the latest released provider and then planned with the provider built
+from the pull request, so any non-empty plan is a diff that users would see after upgrading. The results
+are added to the diff comment posted by generate-comment.
+
+It expects the following arguments:
+	1. PR number
+	2. SHA of the latest magic-modules commit
+	3. Build ID
+	4. Project ID where Cloud Builds are located
+	5. Build step number
+
+The following environment variables are required:
+` + listTTVEnvironmentVariables(),
+	Args: cobra.ExactArgs(5),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		env := make(map[string]string, len(ttvEnvironmentVariables))
+		for _, ev := range ttvEnvironmentVariables {
+			val, ok := os.LookupEnv(ev)
+			if !ok {
+				return fmt.Errorf("did not provide %s environment variable", ev)
+			}
+			env[ev] = val
+		}
+
+		for _, tokenName := range []string{"GITHUB_TOKEN_DOWNSTREAMS", "GITHUB_TOKEN_MAGIC_MODULES"} {
+			val, ok := lookupGithubTokenOrFallback(tokenName)
+			if !ok {
+				return fmt.Errorf("did not provide %s or GITHUB_TOKEN environment variable", tokenName)
+			}
+			env[tokenName] = val
+		}
+
+		gh := github.NewClient(env["GITHUB_TOKEN_MAGIC_MODULES"])
+		rnr, err := exec.NewRunner()
+		if err != nil {
+			return fmt.Errorf("error creating a runner: %w", err)
+		}
+		ctlr := source.NewController(env["GOPATH"], "modular-magician", env["GITHUB_TOKEN_DOWNSTREAMS"], rnr)
+
+		vt, err := vcr.NewTester(env, "", "ci-vcr-logs", rnr)
+		if err != nil {
+			return fmt.Errorf("error creating VCR tester: %w", err)
+		}
+
+		return execTestReleaseDiff(args[0], args[1], args[2], args[3], args[4], gh, rnr, ctlr, vt)
+	},
+}
+
+func execTestReleaseDiff(prNumber, mmCommitSha, buildID, projectID, buildStep string, gh GithubClient, rnr ExecRunner, ctlr *source.Controller, vt *vcr.Tester) error {
+	newBranch := "auto-pr-" + prNumber
+	oldBranch := newBranch + "-old"
+
+	tpgbRepo := &source.Repo{
+		Name:   "terraform-provider-google-beta",
+		Owner:  "modular-magician",
+		Branch: newBranch,
+	}
+	ctlr.SetPath(tpgbRepo)
+	if err := ctlr.Clone(tpgbRepo); err != nil {
+		return fmt.Errorf("error cloning repo: %w", err)
+	}
+	if err := ctlr.Fetch(tpgbRepo, oldBranch); err != nil {
+		return fmt.Errorf("failed to fetch old branch: %w", err)
+	}
+	changedFiles, err := ctlr.DiffNameOnly(tpgbRepo, oldBranch, newBranch)
+	if err != nil {
+		return fmt.Errorf("failed to compute name-only diff: %w", err)
+	}
+	vt.SetRepoPath(provider.Beta, tpgbRepo.Path)
+
+	// Release diff tests run against real APIs, so never run the full suite.
+	services, _ := modifiedPackages(changedFiles, provider.Beta)
+	if len(services) == 0 {
+		fmt.Println("Skipping release diff tests: No service packages changed")
+		return nil
+	}
+
+	buildStatusTargetURL := fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds;region=global/%s;step=%s?project=%s", buildID, buildStep, projectID)
+	if err := gh.PostBuildStatus(prNumber, "release-diff-test", "pending", buildStatusTargetURL, mmCommitSha); err != nil {
+		return fmt.Errorf("error posting pending status: %w", err)
+	}
+
+	var servicesArr []string
+	for s := range services {
+		servicesArr = append(servicesArr, s)
+	}
+	sort.Strings(servicesArr)
+
+	result := vcr.Result{}
+	for _, service := range servicesArr {
+		servicePath := "./" + filepath.Join(provider.Beta.ProviderName(), "services", service)
+		fmt.Println("run release diff tests in ", service)
+		serviceResult, err := vt.Run(vcr.RunOptions{
+			Mode:     vcr.ReleaseDiff,
+			Version:  provider.Beta,
+			TestDirs: []string{servicePath},
+		})
+		if err != nil {
+			fmt.Printf("Error running release diff tests in %s: %v\n", service, err)
+		}
+		result.PassedTests = append(result.PassedTests, serviceResult.PassedTests...)
+		result.SkippedTests = append(result.SkippedTests, serviceResult.SkippedTests...)
+		result.FailedTests = append(result.FailedTests, serviceResult.FailedTests...)
+		result.Panics = append(result.Panics, serviceResult.Panics...)
+	}
+
+	if err := vt.UploadLogs(vcr.UploadLogsOptions{
+		Head:    newBranch,
+		BuildID: buildID,
+		Mode:    vcr.ReleaseDiff,
+		Version: provider.Beta,
+	}); err != nil {
+		return fmt.Errorf("error uploading release diff logs: %w", err)
+	}
+
+	output, err := vt.ReadTestLog(vcr.ReleaseDiff)
+	if err != nil {
+		return fmt.Errorf("error reading release diff test log: %w", err)
+	}
+	diffTests, otherFailedTests := classifyReleaseDiffFailures(output, result.FailedTests)
+
+	comment, err := formatReleaseDiff(releaseDiff{
+		Services:         servicesArr,
+		Result:           result,
+		DiffTests:        diffTests,
+		OtherFailedTests: otherFailedTests,
+		LogBucket:        "ci-vcr-logs",
+		Version:          provider.Beta.String(),
+		Head:             newBranch,
+		BuildID:          buildID,
+	})
+	if err != nil {
+		return fmt.Errorf("error formatting release diff comment: %w", err)
+	}
+	if err := updateDiffComment(prNumber, comment, gh); err != nil {
+		return err
+	}
+
+	testState := "success"
+	if len(diffTests) > 0 || len(result.Panics) > 0 {
+		testState = "failure"
+	}
+	if err := gh.PostBuildStatus(prNumber, "release-diff-test", testState, buildStatusTargetURL, mmCommitSha); err != nil {
+		return fmt.Errorf("error posting build status: %w", err)
+	}
+	return nil
+}
+
+// classifyReleaseDiffFailures splits failed tests into those that failed because the local
+// provider planned a diff on a config applied by the released provider, and all others.
+func classifyReleaseDiffFailures(output string, failedTests []string) ([]string, []string) {
+	diffTests := vcr.ReleaseDiffTests(output, failedTests)
+	isDiff := make(map[string]bool, len(diffTests))
+	for _, test := range diffTests {
+		isDiff[test] = true
+	}
+	otherFailedTests := []string{}
+	for _, test := range failedTests {
+		if !isDiff[test] {
+			otherFailedTests = append(otherFailedTests, test)
+		}
+	}
+	sort.Strings(otherFailedTests)
+	return diffTests, otherFailedTests
+}
+
+func formatReleaseDiff(data releaseDiff) (string, error) {
+	section, err := formatComment("release_diff.tmpl", releaseDiffTmplText, data)
+	if err != nil {
+		return "", err
+	}
+	return releaseDiffStartMarker + "\n" + strings.TrimSpace(section) + "\n" + releaseDiffEndMarker, nil
+}
+
+// updateDiffComment adds section to the latest diff comment on the PR, replacing the section
+// added by a previous run.
+func updateDiffComment(prNumber, section string, gh GithubClient) error {
+	comments, err := gh.GetPullRequestComments(prNumber)
+	if err != nil {
+		return fmt.Errorf("error getting comments: %w", err)
+	}
+	var diffComment *github.PullRequestComment
+	for i, comment := range comments {
+		if strings.HasPrefix(comment.Body, diffCommentHeader) && (diffComment == nil || !comment.CreatedAt.Before(diffComment.CreatedAt)) {
+			diffComment = &comments[i]
+		}
+	}
+	if diffComment == nil {
+		return fmt.Errorf("no diff comment found on PR %s", prNumber)
+	}
+	if err := gh.UpdateComment(prNumber, replaceReleaseDiffSection(diffComment.Body, section), diffComment.ID); err != nil {
+		return fmt.Errorf("error updating diff comment: %w", err)
+	}
+	return nil
+}
+
+func replaceReleaseDiffSection(body, section string) string {
+	if start := strings.Index(body, releaseDiffStartMarker); start >= 0 {
+		if end := strings.Index(body[start:], releaseDiffEndMarker); end >= 0 {
+			return body[:start] + section + body[start+end+len(releaseDiffEndMarker):]
+		}
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + section + "\n"
+}
+
+func init() {
+	rootCmd.AddCommand(testReleaseDiffCmd)
+}
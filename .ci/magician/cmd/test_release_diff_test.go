@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"magician/github"
+	"magician/provider"
+	"magician/vcr"
+)
+
+const releaseDiffOutput = `=== RUN   TestAccComputeNetwork_basic
+=== PAUSE TestAccComputeNetwork_basic
+=== RUN   TestAccComputeSubnetwork_update
+=== PAUSE TestAccComputeSubnetwork_update
+=== RUN   TestAccComputeFirewall_basic
+=== PAUSE TestAccComputeFirewall_basic
+=== CONT  TestAccComputeNetwork_basic
+=== CONT  TestAccComputeSubnetwork_update
+    resource_compute_subnetwork_test.go:42: Step 2/4 error: After applying this test step, the plan was not empty.
+        stdout:
+        ~ update in-place
+=== NAME  TestAccComputeFirewall_basic
+    resource_compute_firewall_test.go:17: Step 1/2 error: Error running apply: googleapi: Error 403: quota exceeded
+--- FAIL: TestAccComputeSubnetwork_update (50.00s)
+--- FAIL: TestAccComputeFirewall_basic (12.00s)
+--- PASS: TestAccComputeNetwork_basic (30.00s)
+`
+
+func TestClassifyReleaseDiffFailures(t *testing.T) {
+	failed := []string{"TestAccComputeFirewall_basic", "TestAccComputeSubnetwork_update"}
+	diffTests, otherFailedTests := classifyReleaseDiffFailures(releaseDiffOutput, failed)
+	if expected := []string{"TestAccComputeSubnetwork_update"}; !reflect.DeepEqual(diffTests, expected) {
+		t.Errorf("wrong diff tests: got %v, expected %v", diffTests, expected)
+	}
+	if expected := []string{"TestAccComputeFirewall_basic"}; !reflect.DeepEqual(otherFailedTests, expected) {
+		t.Errorf("wrong other failed tests: got %v, expected %v", otherFailedTests, expected)
+	}
+}
+
+func TestReleaseDiffComment(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         releaseDiff
+		wantContains []string
+	}{
+		{
+			name: "new diffs introduced",
+			data: releaseDiff{
+				Services:         []string{"compute", "sql"},
+				Result:           vcr.Result{PassedTests: []string{"a"}, FailedTests: []string{"b", "c"}},
+				DiffTests:        []string{"b"},
+				OtherFailedTests: []string{"c"},
+				LogBucket:        "ci-vcr-logs",
+				Version:          provider.Beta.String(),
+				Head:             "auto-pr-123",
+				BuildID:          "build-123",
+			},
+			wantContains: []string{
+				"## Release diff tests",
+				"Tested services: compute, sql",
+				color("red", "This PR introduces a diff on resources created by the last release."),
+				"<li>b</li>",
+				"1 test(s) failed for other reasons",
+				"<li>c</li>",
+				"https://storage.cloud.google.com/ci-vcr-logs/beta/refs/heads/auto-pr-123/artifacts/build-123/build-log/release_diff_test.log",
+			},
+		},
+		{
+			name: "no diffs",
+			data: releaseDiff{
+				Services: []string{"compute"},
+				Result:   vcr.Result{PassedTests: []string{"a"}},
+			},
+			wantContains: []string{
+				color("green", "No new diffs were introduced compared to the last release."),
+				"Passed: 1 | Skipped: 0",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := formatReleaseDiff(tc.data)
+			if err != nil {
+				t.Fatalf("Failed to format comment: %v", err)
+			}
+			for _, wc := range tc.wantContains {
+				if !strings.Contains(got, wc) {
+					t.Errorf("formatReleaseDiff() returned %q, which does not contain %q", got, wc)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateDiffComment(t *testing.T) {
+	section, err := formatReleaseDiff(releaseDiff{
+		Services: []string{"compute"},
+		Result:   vcr.Result{PassedTests: []string{"a"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to format comment: %v", err)
+	}
+	oldDiffComment := diffCommentHeader + "\n\n## Diff report\nold"
+	newDiffComment := diffCommentHeader + "\n\n## Diff report\nnew\n"
+	gh := &mockGithub{
+		pullRequestComments: []github.PullRequestComment{
+			{ID: 1, Body: oldDiffComment, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: 2, Body: "Thanks for the PR!", CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+			{ID: 3, Body: newDiffComment + "\n" + releaseDiffStartMarker + "\nstale\n" + releaseDiffEndMarker + "\n", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		calledMethods: make(map[string][][]any),
+	}
+
+	if err := updateDiffComment("123", section, gh); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := gh.calledMethods["PostComment"]; ok {
+		t.Errorf("expected no new comment to be posted")
+	}
+	calls := gh.calledMethods["UpdateComment"]
+	if len(calls) != 1 {
+		t.Fatalf("expected the diff comment to be updated once, got %v", calls)
+	}
+	body, id := calls[0][1].(string), calls[0][2].(int)
+	if id != 3 {
+		t.Errorf("expected the latest diff comment to be updated, got %d", id)
+	}
+	if expected := newDiffComment + "\n" + section + "\n"; body != expected {
+		t.Errorf("wrong comment body: got %q, expected %q", body, expected)
+	}
+}
+
+func TestUpdateDiffCommentWithoutDiffComment(t *testing.T) {
+	gh := &mockGithub{calledMethods: make(map[string][][]any)}
+	if err := updateDiffComment("123", "section", gh); err == nil {
+		t.Errorf("expected an error without a diff comment")
+	}
+}
+
+func TestReplaceReleaseDiffSection(t *testing.T) {
+	section := releaseDiffStartMarker + "\nnew\n" + releaseDiffEndMarker
+	if got, expected := replaceReleaseDiffSection("diff\n", section), "diff\n\n"+section+"\n"; got != expected {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+	body := "diff\n\n" + releaseDiffStartMarker + "\nold\n" + releaseDiffEndMarker + "\nfooter"
+	if got, expected := replaceReleaseDiffSection(body, section), "diff\n\n"+section+"\nfooter"; got != expected {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+}
@@ -30,13 +30,14 @@ type Label struct {
 }
 
 type PullRequest struct {
-	HTMLUrl        string  `json:"html_url"`
-	Number         int     `json:"number"`
-	Title          string  `json:"title"`
-	User           User    `json:"user"`
-	Body           string  `json:"body"`
-	Labels         []Label `json:"labels"`
-	MergeCommitSha string  `json:"merge_commit_sha"`
+	HTMLUrl            string  `json:"html_url"`
+	Number             int     `json:"number"`
+	Title              string  `json:"title"`
+	User               User    `json:"user"`
+	Body               string  `json:"body"`
+	Labels             []Label `json:"labels"`
+	MergeCommitSha     string  `json:"merge_commit_sha"`
+	RequestedReviewers []User  `json:"requested_reviewers"`
 }
 
 type PullRequestComment struct {
@@ -66,6 +67,24 @@ func (gh *Client) GetPullRequests(state, base, sort, direction string) ([]PullRe
 	return pullRequests, err
 }
 
+// GetAllPullRequests is GetPullRequests for every page of results, rather than only the first.
+func (gh *Client) GetAllPullRequests(state, base, sort, direction string) ([]PullRequest, error) {
+	const perPage = 100
+	var all []PullRequest
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("https://api.github.com/repos/GoogleCloudPlatform/magic-modules/pulls?state=%s&base=%s&sort=%s&direction=%s&per_page=%d&page=%d", state, base, sort, direction, perPage, page)
+
+		var pullRequests []PullRequest
+		if err := utils.RequestCall(url, "GET", gh.token, &pullRequests, nil); err != nil {
+			return nil, err
+		}
+		all = append(all, pullRequests...)
+		if len(pullRequests) < perPage {
+			return all, nil
+		}
+	}
+}
+
 func (gh *Client) GetPullRequestRequestedReviewers(prNumber string) ([]User, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/GoogleCloudPlatform/magic-modules/pulls/%s/requested_reviewers", prNumber)
 
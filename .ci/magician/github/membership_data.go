@@ -23,6 +23,17 @@ var (
 	// This is for new team members who are onboarding
 	trustedContributors = map[string]struct{}{}
 
+	// This is for expertise-aware assignment: reviewers listed for a service label are preferred
+	// for pull requests with that label. Labels must match keys in
+	// tools/issue-labeler/labeler/enrolled_teams.yml. For example:
+	// "xyz": {"service/compute-instances", "service/compute-vpc"},
+	reviewerExpertise = map[string][]string{}
+
+	// This is for availability-aware assignment: reviewers are preferred during their working
+	// hours. Reviewers without an entry are treated as always available. For example:
+	// "xyz": {loc: pdtLoc, startHour: 9, endHour: 17},
+	reviewerWorkingHours = map[string]workingHours{}
+
 	// This is for reviewers who are "on vacation": will not receive new review assignments but will still receive re-requests for assigned PRs.
 	// User can specify the time zone like this, and following the example below:
 	pdtLoc, _           = time.LoadLocation("America/Los_Angeles")
@@ -19,7 +19,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
 	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
 )
 
 func TestTrustedContributors(t *testing.T) {
@@ -38,6 +40,34 @@ func TestOnVacationReviewers(t *testing.T) {
 	}
 }
 
+func TestReviewerExpertise(t *testing.T) {
+	enrolledTeams := make(map[string]labeler.LabelData)
+	if err := yaml.Unmarshal(labeler.EnrolledTeamsYaml, &enrolledTeams); err != nil {
+		t.Fatalf("error unmarshalling enrolled teams yaml: %v", err)
+	}
+	for member, labels := range reviewerExpertise {
+		if !IsCoreReviewer(member) {
+			t.Fatalf(`%v is not on reviewerRotation list`, member)
+		}
+		for _, label := range labels {
+			if _, ok := enrolledTeams[label]; !ok {
+				t.Errorf(`%v has expertise in %v, which is not in enrolled_teams.yml`, member, label)
+			}
+		}
+	}
+}
+
+func TestReviewerWorkingHours(t *testing.T) {
+	for member, wh := range reviewerWorkingHours {
+		if !IsCoreReviewer(member) {
+			t.Fatalf(`%v is not on reviewerRotation list`, member)
+		}
+		if wh.loc == nil || wh.startHour >= wh.endHour {
+			t.Fatalf(`%v has invalid working hours %+v`, member, wh)
+		}
+	}
+}
+
 func TestAvailable(t *testing.T) {
 	newYork, err := time.LoadLocation("America/New_York")
 	if err != nil {
@@ -30,7 +30,8 @@ var (
 )
 
 // Returns a list of users to request review from, as well as a new primary reviewer if this is the first run.
-func ChooseCoreReviewers(requestedReviewers, previousReviewers []User) (reviewersToRequest []string, newPrimaryReviewer string) {
+// A new primary reviewer is chosen based on inputs; see ScoreReviewers.
+func ChooseCoreReviewers(requestedReviewers, previousReviewers []User, inputs AssignmentInputs) (reviewersToRequest []string, newPrimaryReviewer string) {
 	hasPrimaryReviewer := false
 	newPrimaryReviewer = ""
 
@@ -49,7 +50,7 @@ func ChooseCoreReviewers(requestedReviewers, previousReviewers []User) (reviewer
 	}
 
 	if !hasPrimaryReviewer {
-		newPrimaryReviewer = GetRandomReviewer()
+		newPrimaryReviewer = ChooseReviewer(inputs)
 		reviewersToRequest = append(reviewersToRequest, newPrimaryReviewer)
 	}
 
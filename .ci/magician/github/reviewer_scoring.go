@@ -0,0 +1,159 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package github
+
+import (
+	"fmt"
+	utils "magician/utility"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Weights used to score reviewers. Expertise outweighs load so that a service expert with a
+// couple of open reviews is still preferred over an idle reviewer without expertise.
+const (
+	expertiseWeight    = 10
+	openReviewWeight   = 3
+	workingHoursWeight = 5
+)
+
+type workingHours struct {
+	loc       *time.Location
+	startHour int // inclusive
+	endHour   int // exclusive
+}
+
+// AssignmentInputs is what is known about a pull request when choosing its primary reviewer.
+type AssignmentInputs struct {
+	Now           time.Time
+	ServiceLabels []string       // service/* labels on the pull request
+	OpenReviews   map[string]int // reviewer login to the number of open pull requests they are reviewing
+	Exclude       []string       // reviewers that must not be chosen, e.g. the current primary reviewer
+}
+
+// ReviewerScore explains how an available reviewer was scored for a pull request.
+type ReviewerScore struct {
+	Reviewer       string
+	OpenReviews    int
+	Expertise      []string // service labels on the pull request that the reviewer is an expert in
+	InWorkingHours bool
+	Score          int
+}
+
+func (rs ReviewerScore) String() string {
+	expertise := "none"
+	if len(rs.Expertise) > 0 {
+		expertise = strings.Join(rs.Expertise, ", ")
+	}
+	return fmt.Sprintf("%s: score %d (open reviews: %d, expertise: %s, in working hours: %t)", rs.Reviewer, rs.Score, rs.OpenReviews, expertise, rs.InWorkingHours)
+}
+
+// ScoreReviewers scores every available reviewer for a pull request, best first.
+func ScoreReviewers(inputs AssignmentInputs) []ReviewerScore {
+	reviewers := available(inputs.Now, maps.Keys(reviewerRotation), onVacationReviewers)
+	return scoreReviewers(inputs, reviewers, reviewerExpertise, reviewerWorkingHours)
+}
+
+func scoreReviewers(inputs AssignmentInputs, reviewers []string, expertise map[string][]string, hours map[string]workingHours) []ReviewerScore {
+	reviewers = utils.Removes(reviewers, inputs.Exclude)
+	scores := make([]ReviewerScore, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		rs := ReviewerScore{
+			Reviewer:       reviewer,
+			OpenReviews:    inputs.OpenReviews[reviewer],
+			InWorkingHours: inWorkingHours(inputs.Now, hours[reviewer]),
+		}
+		for _, label := range inputs.ServiceLabels {
+			if slices.Contains(expertise[reviewer], label) {
+				rs.Expertise = append(rs.Expertise, label)
+			}
+		}
+		rs.Score = expertiseWeight*len(rs.Expertise) - openReviewWeight*rs.OpenReviews
+		if rs.InWorkingHours {
+			rs.Score += workingHoursWeight
+		}
+		scores = append(scores, rs)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Reviewer < scores[j].Reviewer
+	})
+	return scores
+}
+
+// ChooseReviewer returns a random reviewer among those with the best score, or an empty string
+// if no reviewer is available.
+func ChooseReviewer(inputs AssignmentInputs) string {
+	scores := ScoreReviewers(inputs)
+	if len(scores) == 0 {
+		return ""
+	}
+	best := 1
+	for best < len(scores) && scores[best].Score == scores[0].Score {
+		best++
+	}
+	return scores[rand.Intn(best)].Reviewer
+}
+
+// OpenReviewCounts counts the open pull requests each core reviewer is reviewing: those awaiting
+// their review, and those they have already reviewed. previousReviewers maps a pull request
+// number to the users who have reviewed it.
+func OpenReviewCounts(openPullRequests []PullRequest, previousReviewers map[int][]User) map[string]int {
+	counts := make(map[string]int)
+	for _, pr := range openPullRequests {
+		reviewers := make(map[string]struct{})
+		for _, reviewer := range append(pr.RequestedReviewers, previousReviewers[pr.Number]...) {
+			if IsCoreReviewer(reviewer.Login) {
+				reviewers[reviewer.Login] = struct{}{}
+			}
+		}
+		for reviewer := range reviewers {
+			counts[reviewer]++
+		}
+	}
+	return counts
+}
+
+// ServiceLabels returns the service/* labels of a pull request.
+func ServiceLabels(pr PullRequest) []string {
+	var labels []string
+	for _, label := range pr.Labels {
+		if strings.HasPrefix(label.Name, "service/") {
+			labels = append(labels, label.Name)
+		}
+	}
+	return labels
+}
+
+// inWorkingHours returns true if now is within wh on a weekday. Reviewers without configured
+// working hours are always considered to be within them.
+func inWorkingHours(now time.Time, wh workingHours) bool {
+	if wh.loc == nil {
+		return true
+	}
+	local := now.In(wh.loc)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	return local.Hour() >= wh.startHour && local.Hour() < wh.endHour
+}
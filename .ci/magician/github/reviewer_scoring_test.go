@@ -0,0 +1,153 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScoreReviewers(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tuesday, 10am in Los Angeles.
+	now := time.Date(2024, 3, 5, 10, 0, 0, 0, la)
+
+	tests := []struct {
+		name      string
+		inputs    AssignmentInputs
+		reviewers []string
+		expertise map[string][]string
+		hours     map[string]workingHours
+		want      []string
+	}{
+		{
+			name:      "fewer open reviews scores higher",
+			inputs:    AssignmentInputs{Now: now, OpenReviews: map[string]int{"id1": 3, "id2": 1}},
+			reviewers: []string{"id1", "id2", "id3"},
+			want:      []string{"id3", "id2", "id1"},
+		},
+		{
+			name: "expertise outweighs open reviews",
+			inputs: AssignmentInputs{
+				Now:           now,
+				ServiceLabels: []string{"service/compute-instances"},
+				OpenReviews:   map[string]int{"id1": 2},
+			},
+			reviewers: []string{"id1", "id2"},
+			expertise: map[string][]string{"id1": {"service/compute-instances"}},
+			want:      []string{"id1", "id2"},
+		},
+		{
+			name:      "reviewers outside working hours score lower",
+			inputs:    AssignmentInputs{Now: now},
+			reviewers: []string{"id1", "id2"},
+			hours: map[string]workingHours{
+				"id1": {loc: time.UTC, startHour: 9, endHour: 17},
+				"id2": {loc: la, startHour: 9, endHour: 17},
+			},
+			want: []string{"id2", "id1"},
+		},
+		{
+			name:      "excluded reviewers are not scored",
+			inputs:    AssignmentInputs{Now: now, Exclude: []string{"id1"}},
+			reviewers: []string{"id1", "id2"},
+			want:      []string{"id2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, score := range scoreReviewers(tc.inputs, tc.reviewers, tc.expertise, tc.hours) {
+				got = append(got, score.Reviewer)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("scoreReviewers() returned unexpected order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInWorkingHours(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wh := workingHours{loc: la, startHour: 9, endHour: 17}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		wh   workingHours
+		want bool
+	}{
+		{
+			name: "no working hours configured",
+			now:  time.Date(2024, 3, 9, 3, 0, 0, 0, la),
+			want: true,
+		},
+		{
+			name: "weekday within working hours",
+			now:  time.Date(2024, 3, 5, 9, 0, 0, 0, la),
+			wh:   wh,
+			want: true,
+		},
+		{
+			name: "weekday at end of working hours",
+			now:  time.Date(2024, 3, 5, 17, 0, 0, 0, la),
+			wh:   wh,
+			want: false,
+		},
+		{
+			name: "weekend",
+			now:  time.Date(2024, 3, 9, 10, 0, 0, 0, la),
+			wh:   wh,
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := inWorkingHours(tc.now, tc.wh); got != tc.want {
+				t.Errorf("inWorkingHours() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOpenReviewCounts(t *testing.T) {
+	reviewer := AvailableReviewers()[0]
+	prs := []PullRequest{
+		{Number: 1, RequestedReviewers: []User{{Login: reviewer}, {Login: "foobar"}}},
+		{Number: 2, RequestedReviewers: []User{{Login: reviewer}}},
+		{Number: 3},
+		{Number: 4},
+	}
+	previousReviewers := map[int][]User{
+		// Re-requested after reviewing, so counted once
+		2: {{Login: reviewer}},
+		3: {{Login: reviewer}, {Login: "foobar"}},
+	}
+	want := map[string]int{reviewer: 3}
+	if diff := cmp.Diff(want, OpenReviewCounts(prs, previousReviewers)); diff != "" {
+		t.Errorf("OpenReviewCounts() returned unexpected counts (-want +got):\n%s", diff)
+	}
+}
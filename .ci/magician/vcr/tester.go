@@ -24,9 +24,12 @@ type Mode int
 const (
 	Replaying Mode = iota
 	Recording
+	// ReleaseDiff applies each test config with the released provider and then plans it with
+	// the local one. It runs against real APIs without cassettes.
+	ReleaseDiff
 )
 
-const numModes = 2
+const numModes = 3
 
 func (m Mode) Lower() string {
 	switch m {
@@ -34,6 +37,8 @@ func (m Mode) Lower() string {
 		return "replaying"
 	case Recording:
 		return "recording"
+	case ReleaseDiff:
+		return "release_diff"
 	}
 	return "unknown"
 }
@@ -68,6 +73,13 @@ var testResultsExpression = regexp.MustCompile(`(?m:^--- (PASS|FAIL|SKIP): (Test
 
 var testPanicExpression = regexp.MustCompile(`^panic: .*`)
 
+var testOutputNameExpression = regexp.MustCompile(`^=== (?:RUN|CONT|NAME|PAUSE)\s+(TestAcc\w+)`)
+
+var testResultNameExpression = regexp.MustCompile(`^\s*--- (?:PASS|FAIL|SKIP): (TestAcc\w+)`)
+
+// Matches the terraform-plugin-testing errors reported when a plan after apply is not empty.
+var nonEmptyPlanExpression = regexp.MustCompile(`plan was not empty`)
+
 var safeToLog = map[string]bool{
 	"ACCTEST_PARALLELISM":                        true,
 	"COMMIT_SHA":                                 true,
@@ -95,6 +107,7 @@ var safeToLog = map[string]bool{
 	"GOPATH":                                     true,
 	"HOME":                                       true,
 	"PATH":                                       true,
+	"RELEASE_DIFF":                               true,
 	"SA_KEY":                                     false,
 	"TF_ACC":                                     true,
 	"TF_LOG":                                     true,
@@ -262,6 +275,7 @@ func (vt *Tester) Run(opt RunOptions) (Result, error) {
 	for ev, val := range vt.env {
 		env[ev] = val
 	}
+	setReleaseDiffEnv(opt.Mode, env)
 	var printedEnv string
 	for ev, val := range env {
 		if !safeToLog[ev] {
@@ -409,6 +423,7 @@ func (vt *Tester) runInParallel(mode Mode, version provider.Version, testDir, te
 	for ev, val := range vt.env {
 		env[ev] = val
 	}
+	setReleaseDiffEnv(mode, env)
 	output, testErr := vt.rnr.Run("go", args, env)
 	outputs <- output
 	if testErr != nil {
@@ -430,6 +445,17 @@ func (vt *Tester) runInParallel(mode Mode, version provider.Version, testDir, te
 	wg.Done()
 }
 
+// setReleaseDiffEnv replaces the VCR settings in env with RELEASE_DIFF in ReleaseDiff mode, since
+// the acceptance test framework only runs release diff tests when VCR is disabled.
+func setReleaseDiffEnv(mode Mode, env map[string]string) {
+	if mode != ReleaseDiff {
+		return
+	}
+	delete(env, "VCR_PATH")
+	delete(env, "VCR_MODE")
+	env["RELEASE_DIFF"] = "true"
+}
+
 func (vt *Tester) makeLogPath(mode Mode, version provider.Version) (string, error) {
 	lgky := logKey{mode, version}
 	logPath, ok := vt.logPaths[lgky]
@@ -584,6 +610,41 @@ func (vt *Tester) printLogs(logPath string) {
 	})
 }
 
+// ReadTestLog returns the output of all tests run in the given mode.
+func (vt *Tester) ReadTestLog(mode Mode) (string, error) {
+	return vt.rnr.ReadFile(filepath.Join(vt.baseDir, "testlogs", fmt.Sprintf("%s_test.log", mode.Lower())))
+}
+
+// ReleaseDiffTests returns the failed tests whose output shows a non-empty plan, meaning the
+// local provider produced a diff on a config applied with the released provider.
+func ReleaseDiffTests(output string, failedTests []string) []string {
+	failed := make(map[string]bool, len(failedTests))
+	for _, test := range failedTests {
+		failed[test] = true
+	}
+	diffs := make(map[string]struct{})
+	var current string
+	for _, line := range strings.Split(output, "\n") {
+		if submatches := testOutputNameExpression.FindStringSubmatch(line); submatches != nil {
+			current = submatches[1]
+			continue
+		}
+		if submatches := testResultNameExpression.FindStringSubmatch(line); submatches != nil {
+			current = submatches[1]
+			continue
+		}
+		if failed[current] && nonEmptyPlanExpression.MatchString(line) {
+			diffs[current] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(diffs))
+	for test := range diffs {
+		result = append(result, test)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func collectResult(output string) Result {
 	matches := testResultsExpression.FindAllStringSubmatch(output, -1)
 	resultSets := make(map[string]map[string]struct{}, 4)
@@ -35,6 +35,12 @@ type Async struct {
 	OpAsync `yaml:",inline"`
 
 	PollAsync `yaml:",inline"`
+
+	// If true, an operation that Terraform stops waiting on before it
+	// finishes, because Terraform was interrupted or the wait timed out, is
+	// recorded in the resource's state, and the next read or delete waits on
+	// it instead of sending a new request.
+	Resumable bool `yaml:"resumable,omitempty"`
 }
 
 func (a Async) Allow(method string) bool {
@@ -158,6 +164,9 @@ func (a *Async) UnmarshalYAML(unmarshal func(any) error) error {
 }
 
 func (a *Async) Validate() {
+	if a.Resumable && a.Result.ResourceInsideResponse {
+		log.Fatalf("`resumable` cannot be used with `resource_inside_response`, as the resource's identity is read from the operation's response.")
+	}
 	if a.Type == "OpAsync" {
 		if a.Operation == nil {
 			log.Fatalf("Missing `Operation` for OpAsync")
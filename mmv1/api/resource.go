@@ -235,6 +235,9 @@ type Resource struct {
 	// An array of function names that determine whether an error is not retryable.
 	ErrorAbortPredicates []string `yaml:"error_abort_predicates,omitempty"`
 
+	// Overrides the provider's retry policy for the resource's requests.
+	RetryPolicy *resource.RetryPolicy `yaml:"retry_policy,omitempty"`
+
 	// Optional attributes for declaring a resource's current version and generating
 	// state_upgrader code to the output .go file from files stored at
 	// mmv1/templates/terraform/state_migrations/
@@ -461,6 +464,13 @@ func (r *Resource) Validate() {
 
 	if r.Async != nil {
 		r.Async.Validate()
+		if r.Async.Resumable && r.Async.IncludeProject && !strings.Contains(r.BaseUrl, "{{project}}") {
+			log.Fatalf("`resumable` async cannot be used with `include_project` in resource %s, which has no project to resume its operations in", r.Name)
+		}
+	}
+
+	if r.RetryPolicy != nil {
+		r.RetryPolicy.Validate(r.Name)
 	}
 }
 
@@ -621,6 +631,13 @@ func (r Resource) GetAsync() *Async {
 	return r.ProductMetadata.Async
 }
 
+// Returns true if the resource resumes waiting on operations that an
+// interrupted apply stopped waiting on.
+func (r Resource) IsResumable() bool {
+	async := r.GetAsync()
+	return async != nil && async.Resumable
+}
+
 // Return the resource-specific identity properties, or a best guess of the
 // `name` value for the resource.
 func (r Resource) GetIdentity() []*Type {
@@ -657,18 +674,25 @@ func (r *Resource) AddLabelsRelatedFields(props []*Type, parent *Type) []*Type {
 }
 
 func (r *Resource) addLabelsFields(props []*Type, parent *Type, labels *Type) []*Type {
-	if parent == nil || parent.FlattenObject {
+	topLevel := parent == nil || parent.FlattenObject
+	if topLevel {
 		if r.ExcludeAttributionLabel {
 			r.CustomDiff = append(r.CustomDiff, "tpgresource.SetLabelsDiffWithoutAttributionLabel")
 		} else {
 			r.CustomDiff = append(r.CustomDiff, "tpgresource.SetLabelsDiff")
 		}
+		// Top-level effective_labels leave out the provider's ignored labels on
+		// read and keep them on update, see the flatten and expand templates.
+		r.CustomDiff = append(r.CustomDiff, "tpgresource.SetIgnoredLabelsDiff")
 	} else if parent.Name == "metadata" {
 		r.CustomDiff = append(r.CustomDiff, "tpgresource.SetMetadataLabelsDiff")
 	}
 
 	terraformLabelsField := buildTerraformLabelsField("labels", parent, labels)
 	effectiveLabelsField := buildEffectiveLabelsField("labels", labels)
+	if topLevel {
+		effectiveLabelsField.Description += " Labels ignored by the provider's `ignore_labels` setting are left out."
+	}
 	props = append(props, terraformLabelsField, effectiveLabelsField)
 
 	// The effective_labels field is used to write to API, instead of the labels field.
@@ -704,8 +728,9 @@ func (r *Resource) addAnnotationsFields(props []*Type, parent *Type, annotations
 		r.CustomDiff = append(r.CustomDiff, "tpgresource.SetMetadataAnnotationsDiff")
 	}
 
+	terraformAnnotationsField := buildTerraformLabelsField("annotations", parent, annotations)
 	effectiveAnnotationsField := buildEffectiveLabelsField("annotations", annotations)
-	props = append(props, effectiveAnnotationsField)
+	props = append(props, terraformAnnotationsField, effectiveAnnotationsField)
 	return props
 }
 
@@ -893,6 +918,75 @@ func (r Resource) ResourceName() string {
 	return fmt.Sprintf("%s%s", r.ProductMetadata.Name, r.Name)
 }
 
+// HasSweeper returns true if a sweeper is generated for the resource. Resources
+// with custom delete code are skipped, as the sweeper can't replicate it.
+func (r Resource) HasSweeper() bool {
+	return !r.ExcludeSweeper && !r.ExcludeDelete && r.CustomCode.CustomDelete == "" && r.CustomCode.PreDelete == "" && r.CustomCode.PostDelete == ""
+}
+
+// SweeperDependencies returns the names of the sweepers that must run before
+// the resource's sweeper: those set in sweeper.dependencies, and those of the
+// resources in the same product that reference this resource through a
+// ResourceRef, since children must be deleted before their parents.
+func (r Resource) SweeperDependencies() []string {
+	deps := slices.Clone(r.Sweeper.Dependencies)
+	if r.ProductMetadata != nil {
+		version := r.ProductMetadata.VersionObjOrClosest(r.TargetVersionName)
+		for _, obj := range r.ProductMetadata.Objects {
+			if obj.Name == r.Name || obj.IsExcluded() || obj.NotInVersion(version) || !obj.HasSweeper() {
+				continue
+			}
+			if obj.referencesResource(r.Name) {
+				deps = append(deps, obj.ResourceName())
+			}
+		}
+	}
+	sort.Strings(deps)
+	return slices.Compact(deps)
+}
+
+// ValidateSweeperDependencies returns an error if the sweeper.dependencies of a resource name a
+// sweeper that isn't registered: neither generated for a resource in products at its version, nor
+// in handwritten, the names of the handwritten sweepers. The sweeper would fail to run otherwise.
+func ValidateSweeperDependencies(products []*Product, handwritten []string) error {
+	registered := make(map[string]bool)
+	for _, name := range handwritten {
+		registered[name] = true
+	}
+	var swept []*Resource
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.IsExcluded() || !r.HasSweeper() || r.NotInVersion(p.VersionObjOrClosest(r.TargetVersionName)) {
+				continue
+			}
+			registered[r.ResourceName()] = true
+			swept = append(swept, r)
+		}
+	}
+	for _, r := range swept {
+		for _, dep := range r.Sweeper.Dependencies {
+			if !registered[dep] {
+				return fmt.Errorf("sweeper dependency %s of resource %s is not a registered sweeper", dep, r.ResourceName())
+			}
+		}
+	}
+	return nil
+}
+
+// referencesResource returns true if any of the resource's properties, or the
+// items of its array properties, are a ResourceRef to the named resource.
+// Names are compared case-insensitively, as some products use lowercase names.
+func (r Resource) referencesResource(name string) bool {
+	for _, p := range r.AllNestedProperties(r.AllUserProperties()) {
+		for _, t := range []*Type{p, p.ItemType} {
+			if t != nil && t.IsA("ResourceRef") && strings.EqualFold(t.Resource, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Filter the properties to keep only the ones don't have custom update
 // method and group them by update url & verb.
 func propertiesWithoutCustomUpdate(properties []*Type) []*Type {
@@ -0,0 +1,84 @@
+// Copyright 2024 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"log"
+	"strconv"
+	"time"
+)
+
+// Overrides the provider's retry policy for requests sent by a resource,
+// e.g. for an API that rate limits aggressively. Unset fields keep the value
+// configured in the provider's `retry_policy` block.
+type RetryPolicy struct {
+	// The wait before the first retry, as a duration string, e.g. "2s".
+	InitialBackoff string `yaml:"initial_backoff,omitempty"`
+
+	// The maximum wait between attempts, as a duration string, e.g. "1m".
+	MaxBackoff string `yaml:"max_backoff,omitempty"`
+
+	// The factor the wait grows by after each retry.
+	Multiplier float64 `yaml:"multiplier,omitempty"`
+
+	// Whether to wait for a random duration up to the computed wait.
+	Jitter *bool `yaml:"jitter,omitempty"`
+
+	// The maximum number of attempts at a request, including the first.
+	MaxAttempts int `yaml:"max_attempts,omitempty"`
+
+	// Whether to wait at least as long as a response's Retry-After header asks for.
+	HonorRetryAfter *bool `yaml:"honor_retry_after,omitempty"`
+}
+
+func (p *RetryPolicy) Validate(rName string) {
+	for _, d := range []struct{ field, value string }{
+		{"initial_backoff", p.InitialBackoff},
+		{"max_backoff", p.MaxBackoff},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v <= 0 {
+			log.Fatalf("Invalid `%s` %q for `retry_policy` in resource %s", d.field, d.value, rName)
+		}
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		log.Fatalf("`multiplier` for `retry_policy` in resource %s must be at least 1", rName)
+	}
+	if p.MaxAttempts < 0 {
+		log.Fatalf("`max_attempts` for `retry_policy` in resource %s must not be negative", rName)
+	}
+}
+
+// Returns the initial backoff as a Go duration expression, e.g. "2000 * time.Millisecond".
+func (p RetryPolicy) InitialBackoffExpr() string {
+	return durationExpr(p.InitialBackoff)
+}
+
+// Returns the max backoff as a Go duration expression, e.g. "60000 * time.Millisecond".
+func (p RetryPolicy) MaxBackoffExpr() string {
+	return durationExpr(p.MaxBackoff)
+}
+
+func durationExpr(s string) string {
+	if s == "" {
+		return ""
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		log.Fatalf("Invalid duration %q: %v", s, err)
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10) + " * time.Millisecond"
+}
@@ -17,4 +17,11 @@ type Sweeper struct {
 	// The field checked by sweeper to determine
 	// eligibility for deletion for generated resources
 	SweepableIdentifierField string `yaml:"sweepable_identifier_field"`
+
+	// Names of sweepers that must run before this resource's sweeper, such
+	// as those of child resources in other products, e.g. "ComputeNetwork".
+	// Each must be a generated or handwritten sweeper in the same provider
+	// version. Sweepers of resources in the same product that reference this
+	// resource are added automatically.
+	Dependencies []string `yaml:"dependencies,omitempty"`
 }
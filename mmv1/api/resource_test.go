@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -198,3 +199,112 @@ func TestResourceServiceVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceSweeperDependencies(t *testing.T) {
+	t.Parallel()
+
+	p := &Product{
+		Name: "Test",
+		Versions: []*product.Version{
+			{Name: "ga", BaseUrl: "ga_url"},
+		},
+	}
+	parent := &Resource{
+		Name:            "Parent",
+		ProductMetadata: p,
+		Sweeper:         resource.Sweeper{Dependencies: []string{"OtherProductChild"}},
+	}
+	child := &Resource{
+		Name:            "Child",
+		ProductMetadata: p,
+		Parameters: []*Type{
+			{Name: "parent", Type: "ResourceRef", Resource: "parent"},
+		},
+	}
+	arrayChild := &Resource{
+		Name:            "ArrayChild",
+		ProductMetadata: p,
+		Properties: []*Type{
+			{Name: "parents", Type: "Array", ItemType: &Type{Type: "ResourceRef", Resource: "Parent"}},
+		},
+	}
+	unsweptChild := &Resource{
+		Name:            "UnsweptChild",
+		ProductMetadata: p,
+		ExcludeSweeper:  true,
+		Parameters: []*Type{
+			{Name: "parent", Type: "ResourceRef", Resource: "Parent"},
+		},
+	}
+	p.Objects = []*Resource{parent, child, arrayChild, unsweptChild}
+
+	if got, want := parent.SweeperDependencies(), []string{"OtherProductChild", "TestArrayChild", "TestChild"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v to be %v", got, want)
+	}
+	if got := child.SweeperDependencies(); len(got) != 0 {
+		t.Errorf("expected %v to be empty", got)
+	}
+}
+
+func TestValidateSweeperDependencies(t *testing.T) {
+	t.Parallel()
+
+	p := &Product{
+		Name: "Test",
+		Versions: []*product.Version{
+			{Name: "ga", BaseUrl: "ga_url"},
+			{Name: "beta", BaseUrl: "beta_url"},
+		},
+	}
+	parent := &Resource{
+		Name:              "Parent",
+		ProductMetadata:   p,
+		TargetVersionName: "ga",
+	}
+	betaChild := &Resource{
+		Name:              "BetaChild",
+		ProductMetadata:   p,
+		TargetVersionName: "ga",
+		MinVersion:        "beta",
+	}
+	p.Objects = []*Resource{parent, betaChild}
+
+	cases := map[string]struct {
+		dependencies []string
+		wantErr      bool
+	}{
+		"generated": {
+			dependencies: []string{"TestParent"},
+		},
+		"handwritten": {
+			dependencies: []string{"ComputeInstance"},
+		},
+		"unknown": {
+			dependencies: []string{"ComputeInstances"},
+			wantErr:      true,
+		},
+		"not in version": {
+			dependencies: []string{"TestBetaChild"},
+			wantErr:      true,
+		},
+	}
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			r := &Resource{
+				Name:              "Dependent",
+				ProductMetadata:   p,
+				TargetVersionName: "ga",
+				Sweeper:           resource.Sweeper{Dependencies: tc.dependencies},
+			}
+			products := []*Product{p, {Name: "Other", Objects: []*Resource{r}, Versions: p.Versions}}
+			r.ProductMetadata = products[1]
+			err := ValidateSweeperDependencies(products, []string{"ComputeInstance"})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("ValidateSweeperDependencies() returned %v, want error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
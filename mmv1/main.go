@@ -4,11 +4,13 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -172,6 +174,14 @@ func main() {
 		return strings.Compare(strings.ToLower(p1.Name), strings.ToLower(p2.Name))
 	})
 
+	handwrittenSweepers := readHandwrittenSweepers("third_party/terraform/services")
+	if *overrideDirectory != "" {
+		handwrittenSweepers = append(handwrittenSweepers, readHandwrittenSweepers(filepath.Join(*overrideDirectory, "third_party/terraform/services"))...)
+	}
+	if err := api.ValidateSweeperDependencies(productsForVersion, handwrittenSweepers); err != nil {
+		log.Fatal(err)
+	}
+
 	// In order to only copy/compile files once per provider this must be called outside
 	// of the products loop. This will get called with the provider from the final iteration
 	// of the loop
@@ -185,6 +195,31 @@ func main() {
 	provider.FixImports(*outputPath, *showImportDiffs)
 }
 
+var handwrittenSweeperRegexp = regexp.MustCompile(`sweeper\.AddTestSweepers(?:WithOptions)?\("(\w+)"`)
+
+// readHandwrittenSweepers returns the names of the sweepers registered by the handwritten
+// files in servicesDir.
+func readHandwrittenSweepers(servicesDir string) []string {
+	var names []string
+	err := filepath.WalkDir(servicesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.Contains(d.Name(), "_sweeper.go") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range handwrittenSweeperRegexp.FindAllStringSubmatch(string(data), -1) {
+			names = append(names, match[1])
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("Cannot read handwritten sweepers: %v", err)
+	}
+	return names
+}
+
 func GenerateProduct(productChannel chan string, providerToGenerate provider.Provider, productsForVersionChannel chan *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) {
 
 	defer wg.Done()
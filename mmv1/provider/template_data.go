@@ -139,6 +139,19 @@ func (td *TemplateData) GenerateTestFile(filePath string, resource api.Resource)
 	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
 }
 
+func (td *TemplateData) GenerateMockServerFile(filePath string, product api.Product, resources []api.Resource) {
+	templatePath := "templates/terraform/mock_server.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	tmplInput := MockServerInput{
+		Product:    product,
+		Resources:  resources,
+		ImportPath: td.ImportPath(),
+	}
+	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
 func (td *TemplateData) GenerateIamPolicyFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/iam_policy.go.tmpl"
 	templates := []string{
@@ -281,6 +294,12 @@ func FixImports(outputPath string, dumpDiffs bool) {
 	}
 }
 
+type MockServerInput struct {
+	Product    api.Product
+	Resources  []api.Resource
+	ImportPath string
+}
+
 type TestInput struct {
 	Res                 api.Resource
 	ImportPath          string
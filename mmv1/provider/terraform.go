@@ -78,6 +78,7 @@ func (t Terraform) Generate(outputFolder, productPath, resourceToGenerate string
 
 	if generateCode {
 		t.GenerateOperation(outputFolder)
+		t.GenerateMockServer(outputFolder)
 	}
 }
 
@@ -173,7 +174,7 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 }
 
 func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData TemplateData, outputFolder string) {
-	if object.ExcludeSweeper || object.CustomCode.CustomDelete != "" || object.CustomCode.PreDelete != "" || object.CustomCode.PostDelete != "" || object.ExcludeDelete {
+	if !object.HasSweeper() {
 		return
 	}
 
@@ -204,6 +205,31 @@ func (t *Terraform) GenerateOperation(outputFolder string) {
 	templateData.GenerateOperationFile(targetFilePath, *asyncObjects[0])
 }
 
+// GenerateMockServer generates the acctest.MockResource definitions of the
+// product's resources, used to run tests offline against an acctest.MockServer.
+// Resources nested in a parent's response aren't served by the mock.
+func (t *Terraform) GenerateMockServer(outputFolder string) {
+	var resources []api.Resource
+	for _, object := range t.Product.Objects {
+		if object.IsExcluded() || object.NestedQuery != nil {
+			continue
+		}
+		resources = append(resources, *object)
+	}
+
+	if len(resources) == 0 {
+		return
+	}
+
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_mock_server_test.go", google.Underscore(t.Product.Name)))
+	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	templateData.GenerateMockServerFile(targetFilePath, *t.Product, resources)
+}
+
 // Generate the IAM policy for this object. This is used to query and test
 // IAM policies separately from the resource itself
 func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
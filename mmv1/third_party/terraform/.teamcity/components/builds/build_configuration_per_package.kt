@@ -10,6 +10,11 @@ package builds
 import ArtifactRules
 import DefaultBuildTimeoutDuration
 import DefaultParallelism
+import ProviderNameBeta
+import ProviderNameGa
+import generated.ServiceConfig
+import generated.ServiceConfigBeta
+import generated.ServiceConfigGa
 import generated.ServiceParallelism
 import jetbrains.buildServer.configs.kotlin.BuildType
 import jetbrains.buildServer.configs.kotlin.failureConditions.BuildFailureOnText
@@ -22,6 +27,7 @@ import replaceCharsId
 // Intended to be used in projects where we're testing all packages, e.g. the nightly test projects
 fun BuildConfigurationsForPackages(packages: Map<String, Map<String, String>>, providerName: String, parentProjectName: String, vcsRoot: GitVcsRoot, sharedResources: List<String>, environmentVariables: AccTestConfiguration): List<BuildType> {
     val list = ArrayList<BuildType>()
+    val serviceConfigs = getServiceConfigsInProviderVersion(providerName)
 
     // Create build configurations for all packages, except sweeper
     packages.forEach { (packageName, info) ->
@@ -29,13 +35,22 @@ fun BuildConfigurationsForPackages(packages: Map<String, Map<String, String>>, p
         val displayName: String = info.getValue("displayName").toString()
 
         val pkg = PackageDetails(packageName, displayName, providerName, parentProjectName)
-        val buildConfig = pkg.buildConfiguration(path, vcsRoot, sharedResources, environmentVariables)
+        val buildConfig = pkg.buildConfiguration(path, vcsRoot, sharedResources, environmentVariables, serviceConfig = serviceConfigs[packageName])
         list.add(buildConfig)
     }
 
     return list
 }
 
+// getServiceConfigsInProviderVersion returns the configuration derived from the source of each service package in a provider
+fun getServiceConfigsInProviderVersion(providerName: String): Map<String, ServiceConfig> {
+    return when (providerName) {
+        ProviderNameGa -> ServiceConfigGa
+        ProviderNameBeta -> ServiceConfigBeta
+        else -> mapOf()
+    }
+}
+
 // BuildConfigurationForSinglePackage accepts details of a single package in a provider and returns a build configuration for it
 // Intended to be used in short-lived projects where we're testing specific packages, e.g. feature branch testing
 fun BuildConfigurationForSinglePackage(packageName: String, packagePath: String, packageDisplayName: String, providerName: String, parentProjectName: String, vcsRoot: GitVcsRoot, sharedResources: List<String>, environmentVariables: AccTestConfiguration): BuildType{
@@ -47,16 +62,29 @@ class PackageDetails(private val packageName: String, private val displayName: S
 
     // buildConfiguration returns a BuildType for a service package
     // For BuildType docs, see https://teamcity.jetbrains.com/app/dsl-documentation/root/build-type/index.html
-    fun buildConfiguration(path: String, vcsRoot: GitVcsRoot, sharedResources: List<String>, environmentVariables: AccTestConfiguration, buildTimeout: Int = DefaultBuildTimeoutDuration): BuildType {
+    // If serviceConfig is set, its parallelism and timeout are used instead of the defaults, the build warns if
+    // environment variables read by the package's tests are not set, and the package's sweepers run after its tests.
+    // As those sweepers also run the sweepers they depend on, the build locks the shared resources of the packages
+    // registering them too, so their resources aren't swept while their own tests use them.
+    fun buildConfiguration(path: String, vcsRoot: GitVcsRoot, sharedResources: List<String>, environmentVariables: AccTestConfiguration, buildTimeout: Int = DefaultBuildTimeoutDuration, serviceConfig: ServiceConfig? = null): BuildType {
 
         val testPrefix = "TestAcc"
-        val testTimeout = "12"
 
-        var parallelism = DefaultParallelism
+        var parallelism = serviceConfig?.parallelism ?: DefaultParallelism
+        // Manually curated values take precedence over derived ones
         if (ServiceParallelism.containsKey(packageName)){
             parallelism = ServiceParallelism.getValue(packageName)
         }
 
+        val timeout = maxOf(buildTimeout, serviceConfig?.buildTimeout ?: 0)
+        val testTimeout = "%d".format(timeout / 60) // hours
+        val testEnvironmentVariables = serviceConfig?.environmentVariables ?: listOf()
+        val sweepers = serviceConfig?.sweepers ?: listOf()
+        val lockedPackages = listOf(packageName) + (serviceConfig?.sweeperDependencies ?: listOf())
+        // Service packages are at <provider>/services/<package> and the sweeper package at <provider>/sweeper
+        val sweeperPath = path.substringBefore("/services/") + "/sweeper"
+        val sweeperRegions = "us-central1"
+
         return BuildType {
             // TC needs a consistent ID for dynamically generated packages
             id(uniqueID())
@@ -73,7 +101,9 @@ class PackageDetails(private val packageName: String, private val displayName: S
                 tagBuildToIndicateTriggerMethod()
                 configureGoEnv()
                 downloadTerraformBinary()
+                warnMissingEnvironmentVariables(testEnvironmentVariables)
                 runAcceptanceTests()
+                sweepPackageResources(sweeperPath, sweeperRegions, sweepers)
                 saveArtifactsToGCS()
                 archiveArtifactsIfOverLimit() // Must be after push to GCS step, as this step impacts debug log files
             }
@@ -84,7 +114,9 @@ class PackageDetails(private val packageName: String, private val displayName: S
                     sharedResources {
                         // When the build runs, it locks the value(s) below
                         sharedResources.forEach { sr ->
-                            lockSpecificValue(sr, packageName)
+                            lockedPackages.forEach { p ->
+                                lockSpecificValue(sr, p)
+                            }
                         }
                     }
                 }
@@ -104,7 +136,7 @@ class PackageDetails(private val packageName: String, private val displayName: S
 
             failureConditions {
                 errorMessage = true
-                executionTimeoutMin = buildTimeout
+                executionTimeoutMin = timeout
 
                 // Stop builds if the branch does not exist
                 failOnText {
@@ -7,6 +7,7 @@
 
 package builds
 
+import jetbrains.buildServer.configs.kotlin.BuildStep
 import jetbrains.buildServer.configs.kotlin.BuildSteps
 import jetbrains.buildServer.configs.kotlin.buildSteps.ScriptBuildStep
 
@@ -83,6 +84,43 @@ fun BuildSteps.runSweepers(sweeperStepName: String) {
     })
 }
 
+// sweepPackageResources runs the given sweepers, and the sweepers they depend on, in sweeperRegions from the sweeper
+// package at sweeperPath. It runs even if the tests failed, as failed tests are the most likely to leave resources behind.
+// No step is added if the list is empty.
+fun BuildSteps.sweepPackageResources(sweeperPath: String, sweeperRegions: String, sweepers: List<String>) {
+    if (sweepers.isEmpty()) {
+        return
+    }
+    step(ScriptBuildStep {
+        name = "Sweep package resources"
+        executionMode = BuildStep.ExecutionMode.ALWAYS
+        scriptContent = "go test -v \"$sweeperPath\" -sweep=\"$sweeperRegions\" -sweep-allow-failures -sweep-run=\"${sweepers.joinToString(",")}\" -timeout 30m"
+    })
+}
+
+// warnMissingEnvironmentVariables logs a warning listing any of the given environment variables that are unset, since the
+// tests that read them skip rather than fail. No step is added if the list is empty.
+fun BuildSteps.warnMissingEnvironmentVariables(names: List<String>) {
+    if (names.isEmpty()) {
+        return
+    }
+    step(ScriptBuildStep {
+        name = "Check environment variables"
+        scriptContent = """
+            #!/bin/bash
+            MISSING=""
+            for NAME in ${names.joinToString(" ")}; do
+              if test -z "${'$'}{!NAME}"; then
+                MISSING="${'$'}MISSING ${'$'}NAME"
+              fi
+            done
+            if test -n "${'$'}MISSING"; then
+              echo "##teamcity[message text='Tests that read the following unset environment variables will skip:${'$'}MISSING' status='WARNING']"
+            fi
+        """.trimIndent()
+    })
+}
+
 // RunAcceptanceTests runs tests for a given directory, using either:
 // - TeamCity's test runner - stops remaining tests after a failure
 // - jen20/teamcity-go-test - allows tests to continue after a failure, and requires a test binary
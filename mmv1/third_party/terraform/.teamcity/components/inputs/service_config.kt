@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: MPL-2.0
+ */
+
+// This file is maintained in the GoogleCloudPlatform/magic-modules repository and copied into the downstream provider repositories. Any changes to this file in the downstream will be overwritten.
+
+package generated
+
+// ServiceConfig is the test configuration of a service package, derived from the provider's source by
+// magic-modules/tools/teamcity-generator. See service_config_ga.kt and service_config_beta.kt
+data class ServiceConfig(
+    // The number of acceptance tests that are run in parallel
+    val parallelism: Int,
+    // The build timeout in minutes, long enough to create, update and delete the package's slowest resource
+    val buildTimeout: Int,
+    // Environment variables read by the package's tests, which skip if they are unset
+    val environmentVariables: List<String>,
+    // Names of the sweepers registered by the package
+    val sweepers: List<String>,
+    // Other service packages registering sweepers that the package's sweepers depend on, and so run with them
+    val sweeperDependencies: List<String>
+)
@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: MPL-2.0
+ */
+
+// This file is maintained in the GoogleCloudPlatform/magic-modules repository and copied into the downstream provider repositories. Any changes to this file in the downstream will be overwritten.
+
+/*
+  NOTE: This file is replaced in the downstream provider repository by magic-modules/tools/teamcity-generator, which
+        derives the configuration of each service package from the provider's source:
+            go run . --output <path to provider> --version ga
+        Service packages that are not in the map use the defaults in constants.kt
+*/
+
+package generated
+
+var ServiceConfigGa = mapOf<String, ServiceConfig>()
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: MPL-2.0
+ */
+
+// This file is maintained in the GoogleCloudPlatform/magic-modules repository and copied into the downstream provider repositories. Any changes to this file in the downstream will be overwritten.
+
+package tests
+
+import DefaultBuildTimeoutDuration
+import DefaultParallelism
+import ProviderNameGa
+import SharedResourceNameGa
+import builds.PackageDetails
+import builds.getGaAcceptanceTestConfig
+import generated.ServiceConfig
+import org.junit.Assert.assertEquals
+import org.junit.Assert.assertTrue
+import org.junit.Test
+import vcs_roots.HashiCorpVCSRootGa
+
+class PackageBuildConfigurationTests {
+    @Test
+    fun usesDerivedServiceConfig() {
+        val config = getGaAcceptanceTestConfig(testContextParameters())
+        val serviceConfig = ServiceConfig(
+            parallelism = 2,
+            buildTimeout = 900,
+            environmentVariables = listOf("GOOGLE_ORG"),
+            sweepers = listOf("AlphaWidget"),
+            sweeperDependencies = listOf("beta")
+        )
+
+        val pkg = PackageDetails("alpha", "Alpha", ProviderNameGa, "TestProject")
+        val bt = pkg.buildConfiguration("./google/services/alpha", HashiCorpVCSRootGa, listOf(SharedResourceNameGa), config, serviceConfig = serviceConfig)
+
+        assertEquals("2", bt.params.findRawParam("PARALLELISM")!!.value)
+        assertEquals("15", bt.params.findRawParam("TIMEOUT")!!.value)
+        assertEquals(900, bt.failureConditions.executionTimeoutMin)
+        assertTrue(
+            "Build configuration `${bt.name}` should check its environment variables",
+            bt.steps.items.any { it.name == "Check environment variables" }
+        )
+        assertTrue(
+            "Build configuration `${bt.name}` should sweep its package's resources",
+            bt.steps.items.any { it.name == "Sweep package resources" }
+        )
+    }
+
+    @Test
+    fun usesDefaultsWithoutServiceConfig() {
+        val config = getGaAcceptanceTestConfig(testContextParameters())
+
+        val pkg = PackageDetails("alpha", "Alpha", ProviderNameGa, "TestProject")
+        val bt = pkg.buildConfiguration("./google/services/alpha", HashiCorpVCSRootGa, listOf(SharedResourceNameGa), config)
+
+        assertEquals("%d".format(DefaultParallelism), bt.params.findRawParam("PARALLELISM")!!.value)
+        assertEquals("12", bt.params.findRawParam("TIMEOUT")!!.value)
+        assertEquals(DefaultBuildTimeoutDuration, bt.failureConditions.executionTimeoutMin)
+        assertTrue(bt.steps.items.none { it.name == "Check environment variables" })
+        assertTrue(bt.steps.items.none { it.name == "Sweep package resources" })
+    }
+}
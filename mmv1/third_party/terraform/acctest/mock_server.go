@@ -0,0 +1,556 @@
+package acctest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tpgprovider "github.com/hashicorp/terraform-provider-google/google/provider"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MockOperation is how the API of a MockResource reports that a create,
+// update or delete request completed.
+type MockOperation int
+
+const (
+	// MockOperationNone responds with the resource itself.
+	MockOperationNone MockOperation = iota
+	// MockOperationLongRunning responds with a google.longrunning.Operation
+	// that's already done.
+	MockOperationLongRunning
+	// MockOperationCompute responds with a compute#operation that's already
+	// DONE.
+	MockOperationCompute
+)
+
+// MockResource describes how its API serves a resource to a MockServer. URLs
+// are templates relative to BaseUrl, in the format used by mmv1, e.g.
+// "projects/{{project}}/regions/{{region}}/addresses/{{name}}".
+type MockResource struct {
+	// Name identifies the resource in errors, e.g. "ComputeAddress".
+	Name string
+	// BaseUrl is the base path of the resource's product.
+	BaseUrl string
+	// CollectionUrl is where the resource is listed.
+	CollectionUrl string
+	// CollectionKey is the field of list responses holding the resources.
+	CollectionKey string
+	// CreateUrl is where the resource is created, including any query
+	// parameters holding its id, e.g.
+	// "projects/{{project}}/secrets?secretId={{secret_id}}".
+	CreateUrl  string
+	CreateVerb string
+	// SelfLink is where the resource is read, updated and deleted.
+	SelfLink   string
+	UpdateVerb string
+	Operation  MockOperation
+	// AsyncActions limits Operation to these actions out of "create", "update"
+	// and "delete". The other actions respond with the resource itself.
+	AsyncActions []string
+}
+
+// MockServer is an in-process fake of GCP APIs that stores resources in
+// memory. It's an http.RoundTripper, so it serves the requests of a
+// Config.Client using it as its transport without network access; see
+// MockProtoV5ProviderFactories.
+//
+// Resources are created, read, listed, updated and deleted through the URLs
+// and verbs of their MockResource. Custom methods sent to a resource, such as
+// compute's setLabels, merge their request body into it. Operations are done
+// as soon as they're returned. Resources whose id is assigned by the API,
+// rather than sent in their create request, can't be created.
+type MockServer struct {
+	lock       sync.Mutex
+	routes     []*mockRoute
+	objects    map[string]map[string]interface{}
+	operations map[string]map[string]interface{}
+	count      int
+}
+
+type mockRoute struct {
+	resource    MockResource
+	collection  *mockUrl
+	create      *mockUrl
+	createQuery map[string]string
+	self        *mockUrl
+	method      *regexp.Regexp
+}
+
+// NewMockServer returns a MockServer serving resources.
+func NewMockServer(resources ...MockResource) *MockServer {
+	s := &MockServer{
+		objects:    make(map[string]map[string]interface{}),
+		operations: make(map[string]map[string]interface{}),
+	}
+	s.Register(resources...)
+	return s
+}
+
+// Register makes s serve resources as well, such as those of another product.
+func (s *MockServer) Register(resources ...MockResource) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, r := range resources {
+		if r.CreateVerb == "" {
+			r.CreateVerb = "POST"
+		}
+		if r.UpdateVerb == "" {
+			r.UpdateVerb = "PUT"
+		}
+		if r.CreateUrl == "" {
+			r.CreateUrl = r.CollectionUrl
+		}
+		self := newMockUrl(r.BaseUrl, r.SelfLink)
+		route := &mockRoute{
+			resource:    r,
+			collection:  newMockUrl(r.BaseUrl, r.CollectionUrl),
+			create:      newMockUrl(r.BaseUrl, r.CreateUrl),
+			createQuery: make(map[string]string),
+			self:        self,
+			method:      regexp.MustCompile(`^(` + strings.TrimSuffix(self.re.String()[1:], "$") + `)[/:][A-Za-z]+$`),
+		}
+		if _, query, ok := strings.Cut(r.CreateUrl, "?"); ok {
+			for _, param := range strings.Split(query, "&") {
+				k, v, _ := strings.Cut(param, "=")
+				if m := mockUrlVarRegex.FindStringSubmatch(v); m != nil {
+					route.createQuery[k] = m[2]
+				}
+			}
+		}
+		s.routes = append(s.routes, route)
+	}
+
+	// Try the most specific URLs first, as URLs made only of variables such as
+	// "{{%name}}" match those of other resources.
+	sort.SliceStable(s.routes, func(i, j int) bool {
+		return s.routes[i].self.literalLen() > s.routes[j].self.literalLen()
+	})
+}
+
+// Object returns the resource stored at selfLink, if any.
+func (s *MockServer) Object(selfLink string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	obj, ok := s.objects[selfLink]
+	if !ok {
+		return nil, false
+	}
+	return mockCopy(obj), true
+}
+
+// RoundTrip serves req from the resources stored in s.
+func (s *MockServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body map[string]interface{}
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(b)) > 0 {
+			if err := json.Unmarshal(b, &body); err != nil {
+				code, v := mockError(http.StatusBadRequest, "INVALID_ARGUMENT", "mock: request body isn't a JSON object: %s", err)
+				return mockResponse(req, code, v)
+			}
+		}
+	}
+	if body == nil {
+		body = make(map[string]interface{})
+	}
+
+	u := *req.URL
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	code, v := s.serve(req.Method, u.String(), req.URL.Query(), body)
+	return mockResponse(req, code, v)
+}
+
+func (s *MockServer) serve(method, rawURL string, query url.Values, body map[string]interface{}) (int, interface{}) {
+	if op, ok := s.operations[rawURL]; ok && method == "GET" {
+		return http.StatusOK, op
+	}
+
+	if _, ok := s.objects[rawURL]; ok {
+		for _, r := range s.routes {
+			if _, ok := r.self.match(rawURL); ok {
+				return s.serveObject(r, method, rawURL, query, body)
+			}
+		}
+	}
+
+	for _, r := range s.routes {
+		if vars, ok := r.create.match(rawURL); ok && method == r.resource.CreateVerb {
+			for param, name := range r.createQuery {
+				if v := query.Get(param); v != "" {
+					vars[name] = v
+				}
+			}
+			selfLink, err := r.self.expand(vars, body)
+			if err != nil {
+				return mockError(http.StatusBadRequest, "INVALID_ARGUMENT", "mock: can't create %s: %s", r.resource.Name, err)
+			}
+			return s.insert(r, selfLink, body)
+		}
+	}
+
+	if method == "POST" {
+		for _, r := range s.routes {
+			if m := r.method.FindStringSubmatch(rawURL); m != nil {
+				if obj, ok := s.objects[m[1]]; ok {
+					for k, v := range body {
+						obj[k] = v
+					}
+					return s.done(r, "update", m[1], obj)
+				}
+			}
+		}
+	}
+
+	for _, r := range s.routes {
+		if _, ok := r.collection.match(rawURL); ok && method == "GET" {
+			return http.StatusOK, s.list(r, rawURL)
+		}
+		if _, ok := r.self.match(rawURL); ok {
+			return s.serveObject(r, method, rawURL, query, body)
+		}
+	}
+
+	return mockError(http.StatusNotFound, "NOT_FOUND", "mock: no MockResource serves %s %s", method, rawURL)
+}
+
+func (s *MockServer) serveObject(r *mockRoute, method, selfLink string, query url.Values, body map[string]interface{}) (int, interface{}) {
+	obj, ok := s.objects[selfLink]
+	switch {
+	case !ok && method == r.resource.CreateVerb:
+		return s.insert(r, selfLink, body)
+	case !ok:
+		return mockError(http.StatusNotFound, "NOT_FOUND", "mock: %s %s not found", r.resource.Name, selfLink)
+	case method == "GET":
+		return http.StatusOK, obj
+	case method == "DELETE":
+		delete(s.objects, selfLink)
+		return s.done(r, "delete", selfLink, map[string]interface{}{})
+	case method == r.resource.UpdateVerb:
+		s.update(obj, method, query.Get("updateMask"), body)
+		return s.done(r, "update", selfLink, obj)
+	}
+	return mockError(http.StatusBadRequest, "INVALID_ARGUMENT", "mock: %s doesn't support %s %s", r.resource.Name, method, selfLink)
+}
+
+func (s *MockServer) insert(r *mockRoute, selfLink string, body map[string]interface{}) (int, interface{}) {
+	if _, ok := s.objects[selfLink]; ok {
+		return mockError(http.StatusConflict, "ALREADY_EXISTS", "mock: %s %s already exists", r.resource.Name, selfLink)
+	}
+
+	s.count++
+	obj := mockCopy(body)
+	if _, ok := obj["name"]; !ok {
+		obj["name"] = strings.TrimPrefix(selfLink, r.resource.BaseUrl)
+	}
+	if r.resource.Operation == MockOperationCompute {
+		obj["selfLink"] = selfLink
+		obj["id"] = strconv.Itoa(s.count)
+		obj["creationTimestamp"] = time.Now().Format(time.RFC3339)
+	}
+	s.objects[selfLink] = obj
+	return s.done(r, "create", selfLink, obj)
+}
+
+// update applies body to obj: PUT replaces obj, keeping the fields set by the
+// server, while other verbs merge body into obj, limited to the top-level
+// fields of updateMask if it's set.
+func (s *MockServer) update(obj map[string]interface{}, method, updateMask string, body map[string]interface{}) {
+	if method == "PUT" {
+		for k := range obj {
+			switch k {
+			case "name", "selfLink", "id", "creationTimestamp":
+			default:
+				delete(obj, k)
+			}
+		}
+	}
+
+	if method == "PUT" || updateMask == "" {
+		for k, v := range body {
+			obj[k] = v
+		}
+		return
+	}
+	for _, path := range strings.Split(updateMask, ",") {
+		field, _, _ := strings.Cut(path, ".")
+		field = mockFieldName(field)
+		if v, ok := body[field]; ok {
+			obj[field] = v
+		} else {
+			delete(obj, field)
+		}
+	}
+}
+
+func (s *MockServer) list(r *mockRoute, collection string) map[string]interface{} {
+	var keys []string
+	for k := range s.objects {
+		if name, ok := strings.CutPrefix(k, collection+"/"); ok && !strings.Contains(name, "/") {
+			if _, ok := r.self.match(k); ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	items := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, s.objects[k])
+	}
+	return map[string]interface{}{r.resource.CollectionKey: items}
+}
+
+var mockComputeScopeRegex = regexp.MustCompile(`^projects/[^/]+/(?:(zones|regions)/[^/]+/|global/)?`)
+
+// done returns the response to action, a completed "create", "update" or
+// "delete" of the resource at selfLink.
+func (s *MockServer) done(r *mockRoute, action, selfLink string, obj map[string]interface{}) (int, interface{}) {
+	operation := r.resource.Operation
+	if len(r.resource.AsyncActions) > 0 && !slices.Contains(r.resource.AsyncActions, action) {
+		operation = MockOperationNone
+	}
+
+	s.count++
+	var op map[string]interface{}
+	var opURL string
+	switch operation {
+	case MockOperationLongRunning:
+		name := fmt.Sprintf("operations/mock-%d", s.count)
+		opURL = r.resource.BaseUrl + name
+		op = map[string]interface{}{
+			"name":     name,
+			"done":     true,
+			"response": mockCopy(obj),
+		}
+	case MockOperationCompute:
+		name := fmt.Sprintf("operation-mock-%d", s.count)
+		operationType := action
+		if action == "create" {
+			operationType = "insert"
+		}
+		scope := mockComputeScopeRegex.FindStringSubmatch(strings.TrimPrefix(selfLink, r.resource.BaseUrl))
+		if scope == nil {
+			return mockError(http.StatusBadRequest, "INVALID_ARGUMENT", "mock: can't find the project of %s", selfLink)
+		}
+		prefix := scope[0]
+		if scope[1] == "" {
+			prefix = strings.TrimSuffix(prefix, "global/") + "global/"
+		}
+		opURL = r.resource.BaseUrl + prefix + "operations/" + name
+		op = map[string]interface{}{
+			"kind":          "compute#operation",
+			"name":          name,
+			"operationType": operationType,
+			"progress":      100,
+			"status":        "DONE",
+			"targetLink":    selfLink,
+			"selfLink":      opURL,
+		}
+		if scope[1] == "zones" {
+			op["zone"] = r.resource.BaseUrl + strings.TrimSuffix(prefix, "/")
+		} else if scope[1] == "regions" {
+			op["region"] = r.resource.BaseUrl + strings.TrimSuffix(prefix, "/")
+		}
+	default:
+		return http.StatusOK, obj
+	}
+
+	s.operations[opURL] = op
+	return http.StatusOK, op
+}
+
+var mockUrlVarRegex = regexp.MustCompile(`{{(%?)(\w+)}}`)
+
+// mockUrl matches and builds URLs from a URL template.
+type mockUrl struct {
+	template string
+	vars     []string
+	re       *regexp.Regexp
+}
+
+func newMockUrl(baseUrl, template string) *mockUrl {
+	template, _, _ = strings.Cut(baseUrl+template, "?")
+
+	u := &mockUrl{template: template}
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	last := 0
+	for _, m := range mockUrlVarRegex.FindAllStringSubmatchIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:m[0]]))
+		if m[3] > m[2] || m[0] == len(baseUrl) {
+			// {{%var}} and variables starting a URL, such as {{parent}}, may
+			// hold a path, e.g. "projects/my-project".
+			pattern.WriteString("(.+)")
+		} else {
+			pattern.WriteString("([^/]+)")
+		}
+		u.vars = append(u.vars, template[m[4]:m[5]])
+		last = m[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	u.re = regexp.MustCompile(pattern.String())
+	return u
+}
+
+func (u *mockUrl) literalLen() int {
+	return len(mockUrlVarRegex.ReplaceAllString(u.template, ""))
+}
+
+func (u *mockUrl) match(rawURL string) (map[string]string, bool) {
+	m := u.re.FindStringSubmatch(rawURL)
+	if m == nil {
+		return nil, false
+	}
+	vars := make(map[string]string, len(u.vars))
+	for i, name := range u.vars {
+		vars[name] = m[i+1]
+	}
+	return vars, true
+}
+
+// expand builds the URL from vars, or from the fields of body for variables
+// missing from vars, such as {{name}} from a "name" field.
+func (u *mockUrl) expand(vars map[string]string, body map[string]interface{}) (string, error) {
+	var err error
+	expanded := mockUrlVarRegex.ReplaceAllStringFunc(u.template, func(v string) string {
+		m := mockUrlVarRegex.FindStringSubmatch(v)
+		if value, ok := vars[m[2]]; ok {
+			return value
+		}
+		if value, ok := body[mockFieldName(m[2])].(string); ok && value != "" {
+			if m[1] == "" {
+				value = value[strings.LastIndex(value, "/")+1:]
+			}
+			return value
+		}
+		if err == nil {
+			err = fmt.Errorf("no value for %s", v)
+		}
+		return v
+	})
+	return expanded, err
+}
+
+// mockFieldName returns the JSON field name of a snake_case name.
+func mockFieldName(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func mockCopy(obj map[string]interface{}) map[string]interface{} {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	var c map[string]interface{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func mockError(code int, status, format string, a ...interface{}) (int, interface{}) {
+	return code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": fmt.Sprintf(format, a...),
+			"status":  status,
+		},
+	}
+}
+
+func mockResponse(req *http.Request, code int, v interface{}) (*http.Response, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json; charset=UTF-8"}},
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+		Request:       req,
+	}, nil
+}
+
+const mockAccessToken = "mock-access-token"
+
+// GetMockSDKProvider gets the SDK provider for use in acceptance tests, with
+// its API requests served by server. Its credentials are replaced by a fake
+// access token, and its config is cached for the test like under VCR so
+// CheckDestroy functions use server as well.
+func GetMockSDKProvider(t *testing.T, server *MockServer) *schema.Provider {
+	prov := tpgprovider.Provider()
+	prov.DataSourcesMap["google_provider_config_sdk"] = tpgprovider.DataSourceGoogleProviderConfigSdk()
+
+	testName := t.Name()
+	old := prov.ConfigureContextFunc
+	prov.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		configsLock.RLock()
+		v, ok := configs[testName]
+		configsLock.RUnlock()
+		if ok {
+			return v, nil
+		}
+
+		if err := d.Set("access_token", mockAccessToken); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		c, diags := old(ctx, d)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		config := c.(*transport_tpg.Config)
+		config.Client = &http.Client{Transport: server}
+		config.PollInterval = 10 * time.Millisecond
+
+		configsLock.Lock()
+		configs[testName] = config
+		configsLock.Unlock()
+		return config, diags
+	}
+
+	t.Cleanup(func() {
+		configsLock.Lock()
+		delete(configs, testName)
+		configsLock.Unlock()
+	})
+	return prov
+}
@@ -0,0 +1,217 @@
+package acctest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+var mockTestResources = []acctest.MockResource{
+	{
+		Name:          "SecretManagerSecret",
+		BaseUrl:       "https://secretmanager.googleapis.com/v1/",
+		CollectionUrl: "projects/{{project}}/secrets",
+		CollectionKey: "secrets",
+		CreateUrl:     "projects/{{project}}/secrets?secretId={{secret_id}}",
+		CreateVerb:    "POST",
+		SelfLink:      "projects/{{project}}/secrets/{{secret_id}}",
+		UpdateVerb:    "PATCH",
+	},
+	{
+		Name:          "WorkflowsWorkflow",
+		BaseUrl:       "https://workflows.googleapis.com/v1/",
+		CollectionUrl: "projects/{{project}}/locations/{{region}}/workflows",
+		CollectionKey: "workflows",
+		CreateUrl:     "projects/{{project}}/locations/{{region}}/workflows?workflowId={{name}}",
+		CreateVerb:    "POST",
+		SelfLink:      "projects/{{project}}/locations/{{region}}/workflows/{{name}}",
+		UpdateVerb:    "PATCH",
+		Operation:     acctest.MockOperationLongRunning,
+	},
+	{
+		Name:          "ComputeAddress",
+		BaseUrl:       "https://compute.googleapis.com/compute/v1/",
+		CollectionUrl: "projects/{{project}}/regions/{{region}}/addresses",
+		CollectionKey: "items",
+		CreateUrl:     "projects/{{project}}/regions/{{region}}/addresses",
+		CreateVerb:    "POST",
+		SelfLink:      "projects/{{project}}/regions/{{region}}/addresses/{{name}}",
+		Operation:     acctest.MockOperationCompute,
+		AsyncActions:  []string{"create", "delete", "update"},
+	},
+}
+
+type mockTestClient struct {
+	t      *testing.T
+	client *http.Client
+}
+
+func (c mockTestClient) do(method, url string, body map[string]interface{}) (int, map[string]interface{}) {
+	c.t.Helper()
+
+	var b []byte
+	if body != nil {
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var v map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		c.t.Fatal(err)
+	}
+	return res.StatusCode, v
+}
+
+func TestMockServer_lifecycle(t *testing.T) {
+	server := acctest.NewMockServer(mockTestResources...)
+	c := mockTestClient{t: t, client: &http.Client{Transport: server}}
+
+	secrets := "https://secretmanager.googleapis.com/v1/projects/my-project/secrets"
+	secret := secrets + "/my-secret"
+
+	code, res := c.do("POST", secrets+"?secretId=my-secret", map[string]interface{}{"labels": map[string]interface{}{"foo": "bar"}})
+	if code != http.StatusOK {
+		t.Fatalf("expected create to succeed, got %d: %v", code, res)
+	}
+	if res["name"] != "projects/my-project/secrets/my-secret" {
+		t.Errorf("expected the created secret to be named, got %v", res)
+	}
+
+	if code, _ := c.do("POST", secrets+"?secretId=my-secret", nil); code != http.StatusConflict {
+		t.Errorf("expected creating an existing secret to conflict, got %d", code)
+	}
+
+	code, res = c.do("GET", secrets, nil)
+	if code != http.StatusOK {
+		t.Fatalf("expected list to succeed, got %d: %v", code, res)
+	}
+	if items, ok := res["secrets"].([]interface{}); !ok || len(items) != 1 {
+		t.Errorf("expected one secret to be listed, got %v", res)
+	}
+
+	code, res = c.do("PATCH", secret+"?updateMask=labels", map[string]interface{}{
+		"labels":      map[string]interface{}{"foo": "baz"},
+		"annotations": map[string]interface{}{"ignored": "true"},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("expected update to succeed, got %d: %v", code, res)
+	}
+	obj, ok := server.Object(secret)
+	if !ok {
+		t.Fatalf("expected %s to be stored", secret)
+	}
+	if labels, _ := obj["labels"].(map[string]interface{}); labels["foo"] != "baz" {
+		t.Errorf("expected labels to be updated, got %v", obj)
+	}
+	if _, ok := obj["annotations"]; ok {
+		t.Errorf("expected fields outside of updateMask to be ignored, got %v", obj)
+	}
+
+	if code, res := c.do("POST", secret+":customMethod", map[string]interface{}{"state": "DISABLED"}); code != http.StatusOK {
+		t.Fatalf("expected custom method to succeed, got %d: %v", code, res)
+	}
+	if obj, _ := server.Object(secret); obj["state"] != "DISABLED" {
+		t.Errorf("expected custom method to update the secret, got %v", obj)
+	}
+
+	if code, res := c.do("DELETE", secret, nil); code != http.StatusOK {
+		t.Fatalf("expected delete to succeed, got %d: %v", code, res)
+	}
+	if code, _ := c.do("GET", secret, nil); code != http.StatusNotFound {
+		t.Errorf("expected deleted secret to be not found, got %d", code)
+	}
+	if code, _ := c.do("DELETE", secret, nil); code != http.StatusNotFound {
+		t.Errorf("expected deleting a missing secret to be not found, got %d", code)
+	}
+	if code, _ := c.do("GET", "https://example.com/v1/things", nil); code != http.StatusNotFound {
+		t.Errorf("expected unknown URLs to be not found, got %d", code)
+	}
+}
+
+func TestMockServer_longRunningOperations(t *testing.T) {
+	server := acctest.NewMockServer(mockTestResources...)
+	c := mockTestClient{t: t, client: &http.Client{Transport: server}}
+
+	workflows := "https://workflows.googleapis.com/v1/projects/my-project/locations/us-central1/workflows"
+	code, op := c.do("POST", workflows+"?workflowId=my-workflow", map[string]interface{}{"description": "foo"})
+	if code != http.StatusOK {
+		t.Fatalf("expected create to succeed, got %d: %v", code, op)
+	}
+	if op["done"] != true {
+		t.Errorf("expected a done operation, got %v", op)
+	}
+	if res, _ := op["response"].(map[string]interface{}); res["name"] != "projects/my-project/locations/us-central1/workflows/my-workflow" {
+		t.Errorf("expected the operation response to be the workflow, got %v", op)
+	}
+
+	name, _ := op["name"].(string)
+	code, polled := c.do("GET", "https://workflows.googleapis.com/v1/"+name, nil)
+	if code != http.StatusOK || polled["name"] != name {
+		t.Errorf("expected operation %s to be readable, got %d: %v", name, code, polled)
+	}
+}
+
+func TestMockServer_computeOperations(t *testing.T) {
+	server := acctest.NewMockServer(mockTestResources...)
+	c := mockTestClient{t: t, client: &http.Client{Transport: server}}
+
+	addresses := "https://compute.googleapis.com/compute/v1/projects/my-project/regions/us-central1/addresses"
+	address := addresses + "/my-address"
+
+	code, op := c.do("POST", addresses, map[string]interface{}{"name": "my-address"})
+	if code != http.StatusOK {
+		t.Fatalf("expected create to succeed, got %d: %v", code, op)
+	}
+	if op["kind"] != "compute#operation" || op["status"] != "DONE" || op["operationType"] != "insert" {
+		t.Errorf("expected a DONE insert operation, got %v", op)
+	}
+	if op["targetLink"] != address {
+		t.Errorf("expected the operation to target %s, got %v", address, op)
+	}
+	if op["region"] != "https://compute.googleapis.com/compute/v1/projects/my-project/regions/us-central1" {
+		t.Errorf("expected a regional operation, got %v", op)
+	}
+
+	opURL, _ := op["selfLink"].(string)
+	if code, polled := c.do("GET", opURL, nil); code != http.StatusOK || polled["name"] != op["name"] {
+		t.Errorf("expected operation %s to be readable, got %d: %v", opURL, code, polled)
+	}
+
+	code, obj := c.do("GET", address, nil)
+	if code != http.StatusOK {
+		t.Fatalf("expected read to succeed, got %d: %v", code, obj)
+	}
+	if obj["selfLink"] != address || obj["id"] == nil || obj["creationTimestamp"] == nil {
+		t.Errorf("expected server-set fields on the address, got %v", obj)
+	}
+
+	code, op = c.do("POST", address+"/setLabels", map[string]interface{}{"labels": map[string]interface{}{"foo": "bar"}})
+	if code != http.StatusOK || op["operationType"] != "update" {
+		t.Errorf("expected setLabels to return an update operation, got %d: %v", code, op)
+	}
+	if obj, _ := server.Object(address); obj["labels"] == nil {
+		t.Errorf("expected setLabels to update the address, got %v", obj)
+	}
+
+	code, op = c.do("DELETE", address, nil)
+	if code != http.StatusOK || op["operationType"] != "delete" {
+		t.Errorf("expected delete to return a delete operation, got %d: %v", code, op)
+	}
+	if _, ok := server.Object(address); ok {
+		t.Errorf("expected %s to be deleted", address)
+	}
+}
@@ -0,0 +1,443 @@
+package acctest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// VcrMatcherRule describes request differences that are ignored when matching a request
+// against recorded VCR interactions.
+type VcrMatcherRule struct {
+	// Hosts restricts the rule to requests sent to these API hosts, e.g. "compute.googleapis.com".
+	// An empty list applies the rule to every request.
+	Hosts []string
+	// IgnoreQueryParams are URL query parameters that are dropped before comparing URLs.
+	IgnoreQueryParams []string
+	// IgnoreJSONPaths are dot-separated paths to JSON fields that are dropped before comparing
+	// request bodies. A path without a dot matches a field with that name at any depth.
+	IgnoreJSONPaths []string
+	// CompareHeaders makes request headers part of the match. Headers are not compared by default.
+	CompareHeaders bool
+	// IgnoreHeaders are header names that are never compared, even if CompareHeaders is set.
+	IgnoreHeaders []string
+}
+
+var vcrMatcherRulesLock = sync.RWMutex{}
+
+var vcrMatcherRules = []*VcrMatcherRule{
+	{
+		IgnoreQueryParams: []string{"requestId"},
+		IgnoreJSONPaths:   []string{"requestId"},
+		IgnoreHeaders: []string{
+			"Accept-Encoding",
+			"Authorization",
+			"Content-Length",
+			"User-Agent",
+			"X-Goog-Api-Client",
+		},
+	},
+}
+
+// AddVcrMatcherRule registers an additional matcher rule, typically from a service package's
+// test init() for fields that are generated at request time. It returns a function that
+// removes the rule again.
+func AddVcrMatcherRule(rule VcrMatcherRule) func() {
+	vcrMatcherRulesLock.Lock()
+	defer vcrMatcherRulesLock.Unlock()
+	r := &rule
+	vcrMatcherRules = append(vcrMatcherRules, r)
+	return func() {
+		vcrMatcherRulesLock.Lock()
+		defer vcrMatcherRulesLock.Unlock()
+		vcrMatcherRules = slices.DeleteFunc(vcrMatcherRules, func(other *VcrMatcherRule) bool { return other == r })
+	}
+}
+
+func vcrMatcherRulesForHost(host string) []VcrMatcherRule {
+	vcrMatcherRulesLock.RLock()
+	defer vcrMatcherRulesLock.RUnlock()
+	var rules []VcrMatcherRule
+	for _, rule := range vcrMatcherRules {
+		if len(rule.Hosts) == 0 {
+			rules = append(rules, *rule)
+			continue
+		}
+		for _, h := range rule.Hosts {
+			if strings.EqualFold(h, host) {
+				rules = append(rules, *rule)
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// vcrMismatch describes why a recorded interaction did not match a request. Higher scores
+// mean the interaction got further through matching.
+type vcrMismatch struct {
+	score  int
+	method string
+	url    string
+	reason string
+	diff   []string
+}
+
+// VcrMatcher matches HTTP requests against recorded interactions, remembering the closest
+// interaction for each request so that a replay miss can be explained.
+type VcrMatcher struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	closest map[*http.Request]*vcrMismatch
+}
+
+func NewVcrMatcher(ctx context.Context) *VcrMatcher {
+	return &VcrMatcher{
+		ctx:     ctx,
+		closest: make(map[*http.Request]*vcrMismatch),
+	}
+}
+
+// Match reports whether r matches the recorded request i.
+func (m *VcrMatcher) Match(r *http.Request, i cassette.Request) bool {
+	mismatch := m.compare(r, i)
+	if mismatch == nil {
+		return true
+	}
+	mismatch.method = i.Method
+	mismatch.url = i.URL
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if prev, ok := m.closest[r]; !ok || mismatch.score > prev.score {
+		m.closest[r] = mismatch
+	}
+	return false
+}
+
+// logClosest logs the recorded interaction that came closest to matching r.
+func (m *VcrMatcher) logClosest(r *http.Request) {
+	m.mu.Lock()
+	closest, ok := m.closest[r]
+	m.mu.Unlock()
+	fields := map[string]interface{}{
+		"method": r.Method,
+		"url":    r.URL.String(),
+	}
+	if !ok {
+		tflog.Warn(m.ctx, "VCR found no recorded interaction for request, and no unused interactions remain", fields)
+		return
+	}
+	fields["closest_method"] = closest.method
+	fields["closest_url"] = closest.url
+	fields["mismatch"] = closest.reason
+	fields["diff"] = closest.diff
+	tflog.Warn(m.ctx, "VCR found no recorded interaction for request", fields)
+}
+
+func (m *VcrMatcher) forget(r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.closest, r)
+}
+
+func (m *VcrMatcher) compare(r *http.Request, i cassette.Request) *vcrMismatch {
+	if r.Method != i.Method {
+		return &vcrMismatch{score: 0, reason: "method", diff: []string{fmt.Sprintf("%s != %s", r.Method, i.Method)}}
+	}
+	recordedURL, err := url.Parse(i.URL)
+	if err != nil {
+		return &vcrMismatch{score: 0, reason: "url", diff: []string{err.Error()}}
+	}
+	// Compare against the request's URL as it would have been written to the cassette, so
+	// that redacted query parameters still match
+	actualURL, err := url.Parse(vcrRequestURLForMatching(r))
+	if err != nil {
+		return &vcrMismatch{score: 0, reason: "url", diff: []string{err.Error()}}
+	}
+	if actualURL.Scheme != recordedURL.Scheme || actualURL.Host != recordedURL.Host || actualURL.Path != recordedURL.Path {
+		return &vcrMismatch{score: 1, reason: "path", diff: []string{fmt.Sprintf("%s != %s", actualURL.Path, recordedURL.Path)}}
+	}
+
+	rules := vcrMatcherRulesForHost(r.URL.Host)
+	actualQuery := normalizeVcrQuery(actualURL.Query(), rules)
+	recordedQuery := normalizeVcrQuery(recordedURL.Query(), rules)
+	if actualQuery != recordedQuery {
+		return &vcrMismatch{score: 2, reason: "query", diff: []string{fmt.Sprintf("%s != %s", actualQuery, recordedQuery)}}
+	}
+
+	if diff := diffVcrHeaders(r.Header, i.Headers, rules); len(diff) > 0 {
+		return &vcrMismatch{score: 3, reason: "headers", diff: diff}
+	}
+
+	if r.Body == nil {
+		return nil
+	}
+	var b bytes.Buffer
+	if _, err := b.ReadFrom(r.Body); err != nil {
+		tflog.Debug(m.ctx, fmt.Sprintf("Failed to read request body from cassette: %v", err))
+		return &vcrMismatch{score: 4, reason: "body", diff: []string{err.Error()}}
+	}
+	r.Body = io.NopCloser(&b)
+	if diff := diffVcrBodies(r.Header.Get("Content-Type"), b.String(), i.Headers.Get("Content-Type"), i.Body, r.URL.Host, rules); len(diff) > 0 {
+		return &vcrMismatch{score: 4, reason: "body", diff: diff}
+	}
+	return nil
+}
+
+// normalizeVcrQuery encodes query parameters with keys and repeated values sorted, and
+// ignored parameters removed.
+func normalizeVcrQuery(q url.Values, rules []VcrMatcherRule) string {
+	for _, rule := range rules {
+		for _, param := range rule.IgnoreQueryParams {
+			q.Del(param)
+		}
+	}
+	for _, values := range q {
+		sort.Strings(values)
+	}
+	return q.Encode()
+}
+
+func diffVcrHeaders(actual, recorded http.Header, rules []VcrMatcherRule) []string {
+	compare := false
+	ignored := make(map[string]bool)
+	for _, rule := range rules {
+		compare = compare || rule.CompareHeaders
+		for _, h := range rule.IgnoreHeaders {
+			ignored[http.CanonicalHeaderKey(h)] = true
+		}
+	}
+	if !compare {
+		return nil
+	}
+	keys := make(map[string]bool)
+	for k := range actual {
+		keys[http.CanonicalHeaderKey(k)] = true
+	}
+	for k := range recorded {
+		keys[http.CanonicalHeaderKey(k)] = true
+	}
+	var diff []string
+	for k := range keys {
+		if ignored[k] {
+			continue
+		}
+		if a, r := strings.Join(actual.Values(k), ","), strings.Join(recorded.Values(k), ","); a != r {
+			diff = append(diff, fmt.Sprintf("%s: %q != %q", k, a, r))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// diffVcrBodies compares a request body to a recorded one, returning a description of each
+// difference found.
+func diffVcrBodies(contentType, body, recordedContentType, recordedBody, host string, rules []VcrMatcherRule) []string {
+	// If body matches identically, we are done
+	if body == recordedBody {
+		return nil
+	}
+
+	if strings.Contains(contentType, "multipart/related") {
+		return diffVcrMultipartBodies(contentType, body, recordedContentType, recordedBody, host, rules)
+	}
+	// Sensitive values were scrubbed before the cassette was written, so the request is
+	// scrubbed the same way before comparing
+	redactions := vcrRedactionRulesForHost(host)
+	// JSON might be the same, but reordered. Try parsing json and comparing
+	if strings.Contains(contentType, "application/json") {
+		return diffVcrJSONBodies(body, recordedBody, rules, redactions)
+	}
+	if redactVcrBody(body, redactions) == recordedBody {
+		return nil
+	}
+	return []string{"body differs"}
+}
+
+func diffVcrJSONBodies(body, recordedBody string, rules []VcrMatcherRule, redactions []VcrRedactionRule) []string {
+	var reqJson, cassetteJson interface{}
+	if err := json.Unmarshal([]byte(body), &reqJson); err != nil {
+		return []string{fmt.Sprintf("failed to unmarshal request json: %v", err)}
+	}
+	if err := json.Unmarshal([]byte(recordedBody), &cassetteJson); err != nil {
+		return []string{fmt.Sprintf("failed to unmarshal cassette json: %v", err)}
+	}
+	deleteField := func(obj map[string]interface{}, key string) { delete(obj, key) }
+	for _, rule := range rules {
+		for _, path := range rule.IgnoreJSONPaths {
+			visitVcrJSONPath(reqJson, path, deleteField)
+			visitVcrJSONPath(cassetteJson, path, deleteField)
+		}
+	}
+	return diffVcrJSON(reqJson, cassetteJson, "", redactions)
+}
+
+// diffVcrMultipartBodies compares multipart/related bodies part by part, since each body uses
+// its own randomly generated boundary.
+func diffVcrMultipartBodies(contentType, body, recordedContentType, recordedBody, host string, rules []VcrMatcherRule) []string {
+	parts, err := readVcrMultipartBody(contentType, body)
+	if err != nil {
+		return []string{fmt.Sprintf("failed to read request multipart body: %v", err)}
+	}
+	recordedParts, err := readVcrMultipartBody(recordedContentType, recordedBody)
+	if err != nil {
+		// Older cassettes may not have recorded the boundary, so there's nothing to compare
+		return nil
+	}
+	if len(parts) != len(recordedParts) {
+		return []string{fmt.Sprintf("%d parts != %d parts", len(parts), len(recordedParts))}
+	}
+	var diff []string
+	for i := range parts {
+		for _, d := range diffVcrBodies(parts[i].contentType, parts[i].body, recordedParts[i].contentType, recordedParts[i].body, host, rules) {
+			diff = append(diff, fmt.Sprintf("part %d: %s", i, d))
+		}
+	}
+	return diff
+}
+
+type vcrMultipartPart struct {
+	contentType string
+	body        string
+}
+
+func readVcrMultipartBody(contentType, body string) ([]vcrMultipartPart, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	boundary, ok := params["boundary"]
+	if !ok {
+		return nil, errors.New("no multipart boundary")
+	}
+	reader := multipart.NewReader(strings.NewReader(body), boundary)
+	var parts []vcrMultipartPart
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, vcrMultipartPart{contentType: part.Header.Get("Content-Type"), body: string(b)})
+	}
+}
+
+// diffVcrJSON compares decoded JSON values, returning the path of each difference. A recorded
+// value equal to VcrRedactedValue matches any actual value, and actual strings are compared
+// after the patterns of redactions are replaced.
+func diffVcrJSON(actual, recorded interface{}, path string, redactions []VcrRedactionRule) []string {
+	if recorded == VcrRedactedValue {
+		return nil
+	}
+	switch rec := recorded.(type) {
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: %v != %v", vcrJSONPathOrRoot(path), actual, recorded)}
+		}
+		keys := make(map[string]bool)
+		for k := range act {
+			keys[k] = true
+		}
+		for k := range rec {
+			keys[k] = true
+		}
+		var diff []string
+		for k := range keys {
+			av, aok := act[k]
+			rv, rok := rec[k]
+			switch {
+			case !rok:
+				diff = append(diff, fmt.Sprintf("%s.%s: unexpected field", path, k))
+			case !aok:
+				diff = append(diff, fmt.Sprintf("%s.%s: missing field", path, k))
+			default:
+				diff = append(diff, diffVcrJSON(av, rv, path+"."+k, redactions)...)
+			}
+		}
+		sort.Strings(diff)
+		return diff
+	case []interface{}:
+		act, ok := actual.([]interface{})
+		if !ok || len(act) != len(rec) {
+			return []string{fmt.Sprintf("%s: %v != %v", vcrJSONPathOrRoot(path), actual, recorded)}
+		}
+		var diff []string
+		for i := range rec {
+			diff = append(diff, diffVcrJSON(act[i], rec[i], fmt.Sprintf("%s[%d]", path, i), redactions)...)
+		}
+		return diff
+	case string:
+		if act, ok := actual.(string); ok && (act == rec || redactVcrPatterns(act, redactions) == rec) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: %v != %v", vcrJSONPathOrRoot(path), actual, recorded)}
+	default:
+		if actual != recorded {
+			return []string{fmt.Sprintf("%s: %v != %v", vcrJSONPathOrRoot(path), actual, recorded)}
+		}
+		return nil
+	}
+}
+
+func vcrJSONPathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+// vcrRecorder wraps a go-vcr recorder to explain replay misses using its matcher, and to
+// redact the cassette once it's saved.
+type vcrRecorder struct {
+	*recorder.Recorder
+	matcher   *VcrMatcher
+	cassette  string
+	recording bool
+}
+
+// Stop saves the cassette if it was being recorded, then redacts it.
+func (r *vcrRecorder) Stop() error {
+	if err := r.Recorder.Stop(); err != nil {
+		return err
+	}
+	if !r.recording {
+		return nil
+	}
+	if _, err := os.Stat(r.cassette + ".yaml"); os.IsNotExist(err) {
+		// Cassettes without interactions aren't saved
+		return nil
+	}
+	return RedactVcrCassette(r.cassette)
+}
+
+func (r *vcrRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.Recorder.RoundTrip(req)
+	if errors.Is(err, cassette.ErrInteractionNotFound) {
+		r.matcher.logClosest(req)
+	}
+	r.matcher.forget(req)
+	return resp, err
+}
@@ -0,0 +1,212 @@
+package acctest_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+const multipartRequestBody = "--%[1]s\r\nContent-Type: application/json\r\n\r\n%[2]s\r\n--%[1]s\r\nContent-Type: text/plain\r\n\r\n%[3]s\r\n--%[1]s--\r\n"
+
+func TestVcrMatcher_normalisesRequests(t *testing.T) {
+	t.Cleanup(acctest.AddVcrMatcherRule(acctest.VcrMatcherRule{
+		Hosts:           []string{"pubsub.googleapis.com"},
+		IgnoreJSONPaths: []string{"labels.created"},
+		CompareHeaders:  true,
+	}))
+
+	cases := map[string]struct {
+		httpRequest     requestDescription
+		cassetteRequest requestDescription
+		match           bool
+	}{
+		"matches reordered repeated query parameters": {
+			httpRequest: requestDescription{
+				scheme:   "https",
+				method:   "GET",
+				host:     "example.com",
+				path:     "foobar",
+				rawQuery: "fields=b&fields=a&alt=json",
+			},
+			cassetteRequest: requestDescription{
+				scheme:   "https",
+				method:   "GET",
+				host:     "example.com",
+				path:     "foobar",
+				rawQuery: "alt=json&fields=a&fields=b",
+			},
+			match: true,
+		},
+		"matches when only the ignored requestId query parameter differs": {
+			httpRequest: requestDescription{
+				scheme:   "https",
+				method:   "DELETE",
+				host:     "compute.googleapis.com",
+				path:     "foobar",
+				rawQuery: "requestId=1111",
+			},
+			cassetteRequest: requestDescription{
+				scheme:   "https",
+				method:   "DELETE",
+				host:     "compute.googleapis.com",
+				path:     "foobar",
+				rawQuery: "requestId=2222",
+			},
+			match: true,
+		},
+		"matches when only an ignored JSON path differs": {
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "PUT",
+				host:    "pubsub.googleapis.com",
+				path:    "foobar",
+				headers: map[string]string{"Content-Type": "application/json", "User-Agent": "new"},
+				body:    `{"name":"topic","labels":{"created":"2024-01-02"}}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "PUT",
+				host:    "pubsub.googleapis.com",
+				path:    "foobar",
+				headers: map[string]string{"Content-Type": "application/json", "User-Agent": "old"},
+				body:    `{"labels":{"created":"2023-12-31"},"name":"topic"}`,
+			},
+			match: true,
+		},
+		"does not match when a compared header differs": {
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "GET",
+				host:    "pubsub.googleapis.com",
+				path:    "foobar",
+				headers: map[string]string{"X-Goog-User-Project": "a"},
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "GET",
+				host:    "pubsub.googleapis.com",
+				path:    "foobar",
+				headers: map[string]string{"X-Goog-User-Project": "b"},
+			},
+			match: false,
+		},
+		"matches multipart/related bodies with different boundaries": {
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "storage.googleapis.com",
+				path:    "upload",
+				headers: map[string]string{"Content-Type": "multipart/related; boundary=aaa"},
+				body:    fmt.Sprintf(multipartRequestBody, "aaa", `{"name":"obj","bucket":"b"}`, "hello"),
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "storage.googleapis.com",
+				path:    "upload",
+				headers: map[string]string{"Content-Type": "multipart/related; boundary=bbb"},
+				body:    fmt.Sprintf(multipartRequestBody, "bbb", `{"bucket":"b","name":"obj"}`, "hello"),
+			},
+			match: true,
+		},
+		"does not match multipart/related bodies with different media": {
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "storage.googleapis.com",
+				path:    "upload",
+				headers: map[string]string{"Content-Type": "multipart/related; boundary=aaa"},
+				body:    fmt.Sprintf(multipartRequestBody, "aaa", `{"name":"obj"}`, "hello"),
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "storage.googleapis.com",
+				path:    "upload",
+				headers: map[string]string{"Content-Type": "multipart/related; boundary=bbb"},
+				body:    fmt.Sprintf(multipartRequestBody, "bbb", `{"name":"obj"}`, "goodbye"),
+			},
+			match: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			req := prepareHttpRequest(tc.httpRequest)
+			cassetteReq := prepareCassetteRequest(tc.cassetteRequest)
+			matcher := acctest.NewVcrMatcher(context.Background())
+
+			if got := matcher.Match(req, cassetteReq); got != tc.match {
+				t.Fatalf("expected match to be %t, got %t", tc.match, got)
+			}
+		})
+	}
+}
+
+func TestHandleVCRConfiguration_logsClosestInteraction(t *testing.T) {
+	dir := t.TempDir()
+	c := cassette.New(filepath.Join(dir, "TestAccPubsubTopic_update"))
+	c.AddInteraction(&cassette.Interaction{
+		Request: cassette.Request{
+			Method:  "PATCH",
+			URL:     "https://pubsub.googleapis.com/v1/projects/p/topics/t",
+			Headers: http.Header{"Content-Type": []string{"application/json"}},
+			Body:    `{"labels":{"env":"test"}}`,
+		},
+		Response: cassette.Response{Code: 200, Body: `{}`},
+	})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VCR_MODE", "REPLAYING")
+	t.Setenv("VCR_PATH", dir)
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	_, transport, diags := acctest.HandleVCRConfiguration(ctx, "TestAccPubsubTopic_update", http.DefaultTransport, time.Second)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	req := prepareHttpRequest(requestDescription{
+		scheme:  "https",
+		method:  "PATCH",
+		host:    "pubsub.googleapis.com",
+		path:    "v1/projects/p/topics/t",
+		headers: map[string]string{"Content-Type": "application/json"},
+		body:    `{"labels":{"env":"prod"}}`,
+	})
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Fatalf("expected the changed request not to be replayed")
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, entry := range entries {
+		if entry["@message"] != "VCR found no recorded interaction for request" {
+			continue
+		}
+		found = true
+		if entry["mismatch"] != "body" {
+			t.Errorf("expected a body mismatch, got %v", entry)
+		}
+		if diff := fmt.Sprint(entry["diff"]); !strings.Contains(diff, ".labels.env: prod != test") {
+			t.Errorf("expected the diff to name the changed field, got %v", diff)
+		}
+	}
+	if !found {
+		t.Errorf("expected the closest interaction to be logged, got %v", entries)
+	}
+}
@@ -0,0 +1,230 @@
+package acctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+// VcrRedactedValue replaces any sensitive value before a cassette is written. When replaying,
+// a recorded value equal to VcrRedactedValue matches any value in the outgoing request.
+const VcrRedactedValue = "REDACTED"
+
+// VcrRedactionRule describes values that must be scrubbed from recorded VCR interactions.
+type VcrRedactionRule struct {
+	// Hosts restricts the rule to requests sent to these API hosts, e.g. "sqladmin.googleapis.com".
+	// An empty list applies the rule to every request.
+	Hosts []string
+	// Headers are request and response header names whose values are replaced.
+	Headers []string
+	// JSONPaths are dot-separated paths to JSON fields whose values are replaced in request and
+	// response bodies. A path without a dot matches a field with that name at any depth.
+	JSONPaths []string
+	// QueryParams are URL query parameters whose values are replaced.
+	QueryParams []string
+	// Patterns are regular expressions whose matches are replaced anywhere in request and
+	// response bodies.
+	Patterns []*regexp.Regexp
+}
+
+var vcrRedactionRulesLock = sync.RWMutex{}
+
+var vcrRedactionRules = []*VcrRedactionRule{
+	{
+		Headers:     []string{"Authorization", "Proxy-Authorization", "X-Goog-Api-Key"},
+		QueryParams: []string{"key", "access_token"},
+		JSONPaths: []string{
+			"access_token",
+			"accessToken",
+			"client_secret",
+			"privateKeyData",
+			"private_key",
+			"refresh_token",
+			"refreshToken",
+		},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`ya29\.[0-9A-Za-z_\-]+`),
+			regexp.MustCompile(`-----BEGIN (RSA |EC )?PRIVATE KEY-----[^-]*-----END (RSA |EC )?PRIVATE KEY-----`),
+		},
+	},
+}
+
+// AddVcrRedactionRule registers an additional redaction rule, typically from a service
+// package's test init() for fields such as generated passwords. It returns a function that
+// removes the rule again.
+func AddVcrRedactionRule(rule VcrRedactionRule) func() {
+	vcrRedactionRulesLock.Lock()
+	defer vcrRedactionRulesLock.Unlock()
+	r := &rule
+	vcrRedactionRules = append(vcrRedactionRules, r)
+	return func() {
+		vcrRedactionRulesLock.Lock()
+		defer vcrRedactionRulesLock.Unlock()
+		vcrRedactionRules = slices.DeleteFunc(vcrRedactionRules, func(other *VcrRedactionRule) bool { return other == r })
+	}
+}
+
+// vcrRedactionRulesForHost returns the rules that apply to requests sent to host.
+func vcrRedactionRulesForHost(host string) []VcrRedactionRule {
+	vcrRedactionRulesLock.RLock()
+	defer vcrRedactionRulesLock.RUnlock()
+	var rules []VcrRedactionRule
+	for _, rule := range vcrRedactionRules {
+		if len(rule.Hosts) == 0 {
+			rules = append(rules, *rule)
+			continue
+		}
+		for _, h := range rule.Hosts {
+			if strings.EqualFold(h, host) {
+				rules = append(rules, *rule)
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// RedactVcrCassette scrubs sensitive values from every interaction of the saved cassette
+// name, the cassette path without its .yaml extension. Cassettes are redacted once they're
+// saved rather than as interactions are recorded, because go-vcr builds the response the
+// test receives from the recorded interaction.
+func RedactVcrCassette(name string) error {
+	c, err := cassette.Load(name)
+	if err != nil {
+		return err
+	}
+	for _, i := range c.Interactions {
+		if err := redactVcrInteraction(i); err != nil {
+			return err
+		}
+	}
+	return c.Save()
+}
+
+func redactVcrInteraction(i *cassette.Interaction) error {
+	u, err := url.Parse(i.Request.URL)
+	if err != nil {
+		return err
+	}
+	rules := vcrRedactionRulesForHost(u.Host)
+	i.Request.URL = redactVcrURL(u, rules)
+	i.Request.Headers = redactVcrHeaders(i.Request.Headers, rules)
+	i.Request.Body = redactVcrBody(i.Request.Body, rules)
+	i.Response.Headers = redactVcrHeaders(i.Response.Headers, rules)
+	i.Response.Body = redactVcrBody(i.Response.Body, rules)
+	return nil
+}
+
+func redactVcrURL(u *url.URL, rules []VcrRedactionRule) string {
+	q := u.Query()
+	changed := false
+	for _, rule := range rules {
+		for _, param := range rule.QueryParams {
+			if _, ok := q[param]; ok {
+				q.Set(param, VcrRedactedValue)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	redacted := *u
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+// redactVcrHeaders returns a copy of headers with sensitive values replaced.
+func redactVcrHeaders(headers http.Header, rules []VcrRedactionRule) http.Header {
+	if headers == nil {
+		return nil
+	}
+	redacted := headers.Clone()
+	for _, rule := range rules {
+		for _, name := range rule.Headers {
+			if _, ok := redacted[http.CanonicalHeaderKey(name)]; ok {
+				redacted.Set(name, VcrRedactedValue)
+			}
+		}
+	}
+	return redacted
+}
+
+func redactVcrBody(body string, rules []VcrRedactionRule) string {
+	if body == "" {
+		return body
+	}
+	var paths []string
+	for _, rule := range rules {
+		paths = append(paths, rule.JSONPaths...)
+	}
+	if len(paths) > 0 {
+		var v interface{}
+		if err := json.Unmarshal([]byte(body), &v); err == nil {
+			changed := false
+			for _, path := range paths {
+				visitVcrJSONPath(v, path, func(obj map[string]interface{}, key string) {
+					if obj[key] != VcrRedactedValue {
+						obj[key] = VcrRedactedValue
+						changed = true
+					}
+				})
+			}
+			if changed {
+				if b, err := json.Marshal(v); err == nil {
+					body = string(b)
+				}
+			}
+		}
+	}
+	return redactVcrPatterns(body, rules)
+}
+
+// redactVcrPatterns replaces the matches of the rules' patterns in s.
+func redactVcrPatterns(s string, rules []VcrRedactionRule) string {
+	for _, rule := range rules {
+		for _, pattern := range rule.Patterns {
+			s = pattern.ReplaceAllString(s, VcrRedactedValue)
+		}
+	}
+	return s
+}
+
+// visitVcrJSONPath calls visit with each object holding the field at path within v, a
+// dot-separated path. A path without a dot matches a field with that name at any depth.
+func visitVcrJSONPath(v interface{}, path string, visit func(obj map[string]interface{}, key string)) {
+	visitVcrJSONPathParts(v, strings.Split(path, "."), !strings.Contains(path, "."), visit)
+}
+
+func visitVcrJSONPathParts(v interface{}, path []string, anyDepth bool, visit func(obj map[string]interface{}, key string)) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if k == path[0] {
+				if len(path) == 1 {
+					visit(val, k)
+					continue
+				}
+				visitVcrJSONPathParts(child, path[1:], false, visit)
+			} else if anyDepth {
+				visitVcrJSONPathParts(child, path, true, visit)
+			}
+		}
+	case []interface{}:
+		for _, child := range val {
+			visitVcrJSONPathParts(child, path, anyDepth, visit)
+		}
+	}
+}
+
+// vcrRequestURLForMatching returns the URL of r as it would have been written to a cassette,
+// so that redacted query parameters still match when replaying.
+func vcrRequestURLForMatching(r *http.Request) string {
+	return redactVcrURL(r.URL, vcrRedactionRulesForHost(r.URL.Host))
+}
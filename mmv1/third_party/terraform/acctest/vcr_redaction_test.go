@@ -0,0 +1,148 @@
+package acctest_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+func TestRedactVcrCassette(t *testing.T) {
+	t.Cleanup(acctest.AddVcrRedactionRule(acctest.VcrRedactionRule{
+		Hosts:     []string{"sqladmin.googleapis.com"},
+		JSONPaths: []string{"settings.password"},
+		Patterns:  []*regexp.Regexp{regexp.MustCompile(`tf-test-secret-\w+`)},
+	}))
+
+	name := filepath.Join(t.TempDir(), "TestAccSqlDatabaseInstance_basic")
+	c := cassette.New(name)
+	c.AddInteraction(&cassette.Interaction{
+		Request: cassette.Request{
+			Method: "POST",
+			URL:    "https://sqladmin.googleapis.com/v1/projects/p/instances?key=abc123&alt=json",
+			Headers: http.Header{
+				"Authorization": []string{"Bearer ya29.token"},
+				"Content-Type":  []string{"application/json"},
+			},
+			Body: `{"name":"i","settings":{"password":"hunter2","tier":"db-f1-micro"},"description":"tf-test-secret-abc"}`,
+		},
+		Response: cassette.Response{
+			Body: `{"name":"i","access_token":"ya29.other"}`,
+		},
+	})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := acctest.RedactVcrCassette(name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c, err := cassette.Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interaction := c.Interactions[0]
+	if got := interaction.Request.Headers.Get("Authorization"); got != acctest.VcrRedactedValue {
+		t.Errorf("expected Authorization header to be redacted, got %q", got)
+	}
+	if !strings.Contains(interaction.Request.URL, "key="+acctest.VcrRedactedValue) || !strings.Contains(interaction.Request.URL, "alt=json") {
+		t.Errorf("expected key query parameter to be redacted, got %q", interaction.Request.URL)
+	}
+	for _, leaked := range []string{"hunter2", "tf-test-secret-abc", "ya29.other"} {
+		if strings.Contains(interaction.Request.Body+interaction.Response.Body, leaked) {
+			t.Errorf("expected %q to be redacted, got request %q and response %q", leaked, interaction.Request.Body, interaction.Response.Body)
+		}
+	}
+	if !strings.Contains(interaction.Request.Body, "db-f1-micro") {
+		t.Errorf("expected non-sensitive fields to be kept, got %q", interaction.Request.Body)
+	}
+}
+
+func TestAddVcrRedactionRule_remove(t *testing.T) {
+	remove := acctest.AddVcrRedactionRule(acctest.VcrRedactionRule{
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`tf-test-secret-\w+`)},
+	})
+	remove()
+
+	name := filepath.Join(t.TempDir(), "TestAccFoo")
+	c := cassette.New(name)
+	c.AddInteraction(&cassette.Interaction{
+		Request: cassette.Request{Method: "GET", URL: "https://example.com/foo"},
+		Response: cassette.Response{
+			Body: `{"description":"tf-test-secret-abc"}`,
+		},
+	})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := acctest.RedactVcrCassette(name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, err := cassette.Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := c.Interactions[0].Response.Body; !strings.Contains(body, "tf-test-secret-abc") {
+		t.Errorf("expected a removed rule not to redact, got %q", body)
+	}
+}
+
+func TestNewVcrMatcherFunc_matchesRedactedValues(t *testing.T) {
+	cases := map[string]struct {
+		httpRequest     requestDescription
+		cassetteRequest requestDescription
+	}{
+		"matches JSON bodies where the cassette value was redacted": {
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "iam.googleapis.com",
+				path:    "foobar",
+				headers: map[string]string{"Content-Type": "application/json"},
+				body:    `{"name":"key","privateKeyData":"c2VjcmV0"}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "iam.googleapis.com",
+				path:    "foobar",
+				headers: map[string]string{"Content-Type": "application/json"},
+				body:    `{"privateKeyData":"REDACTED","name":"key"}`,
+			},
+		},
+		"matches non-JSON bodies where a pattern was redacted": {
+			httpRequest: requestDescription{
+				scheme: "https",
+				method: "POST",
+				host:   "example.com",
+				path:   "foobar",
+				body:   "token=ya29.abcdef",
+			},
+			cassetteRequest: requestDescription{
+				scheme: "https",
+				method: "POST",
+				host:   "example.com",
+				path:   "foobar",
+				body:   "token=REDACTED",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			req := prepareHttpRequest(tc.httpRequest)
+			cassetteReq := prepareCassetteRequest(tc.cassetteRequest)
+			matcher := acctest.NewVcrMatcherFunc(context.Background())
+
+			if !matcher(req, cassetteReq) {
+				t.Fatalf("expected matcher to match the requests")
+			}
+		})
+	}
+}
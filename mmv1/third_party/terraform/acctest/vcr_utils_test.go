@@ -315,19 +315,21 @@ func TestNewVcrMatcherFunc_ignoresDifferentUserAgents(t *testing.T) {
 }
 
 type requestDescription struct {
-	scheme  string
-	method  string
-	host    string
-	path    string
-	body    string
-	headers map[string]string
+	scheme   string
+	method   string
+	host     string
+	path     string
+	rawQuery string
+	body     string
+	headers  map[string]string
 }
 
 func prepareHttpRequest(d requestDescription) *http.Request {
 	url := &url.URL{
-		Scheme: d.scheme,
-		Host:   d.host,
-		Path:   d.path,
+		Scheme:   d.scheme,
+		Host:     d.host,
+		Path:     d.path,
+		RawQuery: d.rawQuery,
 	}
 
 	req := &http.Request{
@@ -353,6 +355,9 @@ func prepareHttpRequest(d requestDescription) *http.Request {
 
 func prepareCassetteRequest(d requestDescription) cassette.Request {
 	fullUrl := fmt.Sprintf("%s://%s/%s", d.scheme, d.host, d.path)
+	if d.rawQuery != "" {
+		fullUrl += "?" + d.rawQuery
+	}
 
 	req := cassette.Request{
 		Method: d.method,
@@ -45,6 +45,13 @@ type GoogleProviderConfigPluginFrameworkModel struct {
 	Zone                               types.String `tfsdk:"zone"`
 	Scopes                             types.List   `tfsdk:"scopes"`
 	//	omit Batching
+	//	omit RetryPolicy
+	//	omit RequestRateLimits
+	//	omit ReadOnly
+	//	omit AuditLogPath
+	//	omit IgnoreLabels
+	//	omit DefaultAnnotations
+	//	omit QuotaProjectRouting
 	UserProjectOverride                       types.Bool   `tfsdk:"user_project_override"`
 	RequestTimeout                            types.String `tfsdk:"request_timeout"`
 	RequestReason                             types.String `tfsdk:"request_reason"`
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/hashicorp/terraform-provider-google/google/acctest"
 	"github.com/hashicorp/terraform-provider-google/google/provider"
@@ -1601,3 +1604,102 @@ func TestProvider_ProviderConfigure_batching(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_ProviderConfigure_retryPolicy(t *testing.T) {
+	cases := map[string]struct {
+		ConfigValues        map[string]interface{}
+		ExpectError         bool
+		ExpectedRetryPolicy transport_tpg.RetryPolicy
+	}{
+		"if retry_policy is not set, the default policy is used": {
+			ConfigValues: map[string]interface{}{
+				"credentials": transport_tpg.TestFakeCredentialsPath,
+			},
+			ExpectedRetryPolicy: transport_tpg.DefaultRetryPolicy,
+		},
+		"retry_policy fields override the default policy": {
+			ConfigValues: map[string]interface{}{
+				"credentials": transport_tpg.TestFakeCredentialsPath,
+				"retry_policy": []interface{}{
+					map[string]interface{}{
+						"max_backoff":  "1m",
+						"max_attempts": 10,
+						"jitter":       false,
+					},
+				},
+			},
+			ExpectedRetryPolicy: transport_tpg.RetryPolicy{
+				InitialBackoff:  transport_tpg.DefaultRetryPolicy.InitialBackoff,
+				MaxBackoff:      time.Minute,
+				Multiplier:      transport_tpg.DefaultRetryPolicy.Multiplier,
+				Jitter:          false,
+				MaxAttempts:     10,
+				HonorRetryAfter: true,
+			},
+		},
+		"retry_policy can stop honoring Retry-After headers": {
+			ConfigValues: map[string]interface{}{
+				"credentials": transport_tpg.TestFakeCredentialsPath,
+				"retry_policy": []interface{}{
+					map[string]interface{}{
+						"honor_retry_after": false,
+					},
+				},
+			},
+			ExpectedRetryPolicy: transport_tpg.RetryPolicy{
+				InitialBackoff:  transport_tpg.DefaultRetryPolicy.InitialBackoff,
+				MaxBackoff:      transport_tpg.DefaultRetryPolicy.MaxBackoff,
+				Multiplier:      transport_tpg.DefaultRetryPolicy.Multiplier,
+				Jitter:          transport_tpg.DefaultRetryPolicy.Jitter,
+				MaxAttempts:     transport_tpg.DefaultRetryPolicy.MaxAttempts,
+				HonorRetryAfter: false,
+			},
+		},
+		// Error states
+		"if retry_policy is configured with max_backoff less than initial_backoff, there's an error": {
+			ConfigValues: map[string]interface{}{
+				"credentials": transport_tpg.TestFakeCredentialsPath,
+				"retry_policy": []interface{}{
+					map[string]interface{}{
+						"initial_backoff": "1m",
+						"max_backoff":     "1s",
+					},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+
+			// Arrange
+			ctx := context.Background()
+			acctest.UnsetTestProviderConfigEnvs(t)
+			p := provider.Provider()
+			// Unlike SetupTestResourceDataFromConfigMap, this applies the schema's defaults to the
+			// retry_policy block, as Terraform does when configuring the provider
+			d := schema.TestResourceDataRaw(t, p.Schema, tc.ConfigValues)
+
+			// Act
+			c, diags := provider.ProviderConfigure(ctx, d, p)
+
+			// Assert
+			if diags.HasError() && !tc.ExpectError {
+				t.Fatalf("unexpected error(s): %#v", diags)
+			}
+			if !diags.HasError() && tc.ExpectError {
+				t.Fatal("expected error(s) but got none")
+			}
+			if diags.HasError() {
+				// Return early in tests where errors expected
+				return
+			}
+
+			config := c.(*transport_tpg.Config) // Should be non-nil value, as test cases reaching this point experienced no errors
+			if *config.RetryPolicy != tc.ExpectedRetryPolicy {
+				t.Fatalf("expected retry policy %#v, got %#v", tc.ExpectedRetryPolicy, *config.RetryPolicy)
+			}
+		})
+	}
+}
@@ -29,7 +29,7 @@ func TestAccCloudbuildv2Connection_GheCompleteConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "name"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations", "name"},
 			},
 		},
 	})
@@ -56,7 +56,7 @@ func TestAccCloudbuildv2Connection_GheConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_GheConnectionUpdate0(context),
@@ -65,7 +65,7 @@ func TestAccCloudbuildv2Connection_GheConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -92,7 +92,7 @@ func TestAccCloudbuildv2Connection_GhePrivConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -119,7 +119,7 @@ func TestAccCloudbuildv2Connection_GhePrivUpdateConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_GhePrivUpdateConnectionUpdate0(context),
@@ -128,7 +128,7 @@ func TestAccCloudbuildv2Connection_GhePrivUpdateConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -155,7 +155,7 @@ func TestAccCloudbuildv2Connection_GithubConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_GithubConnectionUpdate0(context),
@@ -164,7 +164,7 @@ func TestAccCloudbuildv2Connection_GithubConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -190,7 +190,7 @@ func TestAccCloudbuildv2Connection_GitlabConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -216,7 +216,7 @@ func TestAccCloudbuildv2Connection_GleConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_GleConnectionUpdate0(context),
@@ -225,7 +225,7 @@ func TestAccCloudbuildv2Connection_GleConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -251,7 +251,7 @@ func TestAccCloudbuildv2Connection_GleOldConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_GleOldConnectionUpdate0(context),
@@ -260,7 +260,7 @@ func TestAccCloudbuildv2Connection_GleOldConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -286,7 +286,7 @@ func TestAccCloudbuildv2Connection_GlePrivConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -312,7 +312,7 @@ func TestAccCloudbuildv2Connection_GlePrivUpdateConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_GlePrivConnection(context),
@@ -321,7 +321,7 @@ func TestAccCloudbuildv2Connection_GlePrivUpdateConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -347,7 +347,7 @@ func TestAccCloudbuildv2Connection_BbdcPrivConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -373,7 +373,7 @@ func TestAccCloudbuildv2Connection_BbdcPrivUpdateConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccCloudbuildv2Connection_BbdcPrivConnection(context),
@@ -382,7 +382,7 @@ func TestAccCloudbuildv2Connection_BbdcPrivUpdateConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -408,7 +408,7 @@ func TestAccCloudbuildv2Connection_BbcConnection(t *testing.T) {
 				ResourceName:            "google_cloudbuildv2_connection.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations"},
 			},
 		},
 	})
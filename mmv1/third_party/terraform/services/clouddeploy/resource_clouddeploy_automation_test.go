@@ -29,7 +29,7 @@ func TestAccClouddeployAutomation_update(t *testing.T) {
 				ResourceName:            "google_clouddeploy_automation.automation",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"location", "delivery_pipeline", "annotations", "labels", "terraform_labels"},
+				ImportStateVerifyIgnore: []string{"location", "delivery_pipeline", "annotations", "terraform_annotations", "labels", "terraform_labels"},
 			},
 			{
 				Config: testAccClouddeployAutomation_update(context),
@@ -38,7 +38,7 @@ func TestAccClouddeployAutomation_update(t *testing.T) {
 				ResourceName:            "google_clouddeploy_automation.automation",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"location", "delivery_pipeline", "annotations", "labels", "terraform_labels"},
+				ImportStateVerifyIgnore: []string{"location", "delivery_pipeline", "annotations", "terraform_annotations", "labels", "terraform_labels"},
 			},
 		},
 	})
@@ -27,7 +27,7 @@ func TestAccClouddeployCustomTargetType_update(t *testing.T) {
 				ResourceName:            "google_clouddeploy_custom_target_type.custom-target-type",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"name", "location", "annotations", "labels", "terraform_labels"},
+				ImportStateVerifyIgnore: []string{"name", "location", "annotations", "terraform_annotations", "labels", "terraform_labels"},
 			},
 			{
 				Config: testAccClouddeployCustomTargetType_update(context),
@@ -36,7 +36,7 @@ func TestAccClouddeployCustomTargetType_update(t *testing.T) {
 				ResourceName:            "google_clouddeploy_custom_target_type.custom-target-type",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"name", "location", "annotations", "labels", "terraform_labels"},
+				ImportStateVerifyIgnore: []string{"name", "location", "annotations", "terraform_annotations", "labels", "terraform_labels"},
 			},
 		},
 	})
@@ -45,7 +45,7 @@ func TestAccClouddeployTarget_withProviderDefaultLabels(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_resourceLabelsOverridesProviderDefaultLabels(context),
@@ -67,7 +67,7 @@ func TestAccClouddeployTarget_withProviderDefaultLabels(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_moveResourceLabelToProviderDefaultLabels(context),
@@ -88,7 +88,7 @@ func TestAccClouddeployTarget_withProviderDefaultLabels(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_resourceLabelsOverridesProviderDefaultLabels(context),
@@ -110,7 +110,7 @@ func TestAccClouddeployTarget_withProviderDefaultLabels(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_withoutLabels(context),
@@ -124,7 +124,7 @@ func TestAccClouddeployTarget_withProviderDefaultLabels(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 		},
 	})
@@ -319,7 +319,7 @@ func TestAccClouddeployTarget_withAttributionDisabled(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_updateWithAttribution(context),
@@ -340,7 +340,7 @@ func TestAccClouddeployTarget_withAttributionDisabled(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_clearWithAttribution(context),
@@ -393,7 +393,7 @@ func TestAccClouddeployTarget_withCreationOnlyAttribution(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_updateWithAttribution(context),
@@ -415,7 +415,7 @@ func TestAccClouddeployTarget_withCreationOnlyAttribution(t *testing.T) {
 				ResourceName:            "google_clouddeploy_target.primary",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations"},
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "annotations", "terraform_annotations"},
 			},
 			{
 				Config: testAccClouddeployTarget_clearWithAttribution(context),
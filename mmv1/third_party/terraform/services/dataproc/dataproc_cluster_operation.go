@@ -29,5 +29,5 @@ func DataprocClusterOperationWait(config *transport_tpg.Config, op *dataproc.Ope
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitWithContext(config.Context, w, activity, timeout, config.PollInterval)
 }
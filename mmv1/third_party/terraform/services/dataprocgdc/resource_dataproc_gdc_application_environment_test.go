@@ -26,7 +26,7 @@ func TestAccDataprocGdcApplicationEnvironment_update(t *testing.T) {
 				ResourceName:            "google_dataproc_gdc_application_environment.application-environment",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "application_environment_id", "labels", "location", "serviceinstance", "terraform_labels"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations", "application_environment_id", "labels", "location", "serviceinstance", "terraform_labels"},
 			},
 			{
 				Config: testAccDataprocGdcApplicationEnvironment_update(context),
@@ -35,7 +35,7 @@ func TestAccDataprocGdcApplicationEnvironment_update(t *testing.T) {
 				ResourceName:            "google_dataproc_gdc_application_environment.application-environment",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "application_environment_id", "labels", "location", "serviceinstance", "terraform_labels"},
+				ImportStateVerifyIgnore: []string{"annotations", "terraform_annotations", "application_environment_id", "labels", "location", "serviceinstance", "terraform_labels"},
 			},
 		},
 	})
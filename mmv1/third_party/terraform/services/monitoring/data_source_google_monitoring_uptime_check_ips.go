@@ -44,7 +44,7 @@ func dataSourceGoogleMonitoringUptimeCheckIpsRead(d *schema.ResourceData, meta i
 		return err
 	}
 
-	url := "https://monitoring.googleapis.com/v3/uptimeCheckIps"
+	url := config.MonitoringBasePath + "v3/uptimeCheckIps"
 
 	uptimeCheckIps, err := tpgresource.PaginatedListRequest("", url, userAgent, config, flattenUptimeCheckIpsList)
 	if err != nil {
@@ -3,6 +3,7 @@ package osconfig
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -10,6 +11,23 @@ import (
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
+// osConfigPreviewVersionRegex matches the version segment at the end of a
+// preview OS Config base path, such as the beta provider's.
+var osConfigPreviewVersionRegex = regexp.MustCompile(`/v1(alpha|beta)\d*/$`)
+
+// osConfigGAUrl returns url, which starts with config's OS Config base path,
+// with the version of the base path replaced by the GA version, as OS policy
+// assignments are only served by the GA API. Other parts of the base path,
+// such as a custom endpoint's host, are kept.
+func osConfigGAUrl(config *transport_tpg.Config, url string) string {
+	base := config.OSConfigBasePath
+	gaBase := osConfigPreviewVersionRegex.ReplaceAllString(base, "/v1/")
+	if gaBase == base || !strings.HasPrefix(url, base) {
+		return url
+	}
+	return gaBase + strings.TrimPrefix(url, base)
+}
+
 type OSConfigOperationWaiter struct {
 	Config    *transport_tpg.Config
 	UserAgent string
@@ -23,7 +41,7 @@ func (w *OSConfigOperationWaiter) QueryOp() (interface{}, error) {
 	}
 	// Returns the proper get.
 	url := fmt.Sprintf("%s%s", w.Config.OSConfigBasePath, w.CommonOperationWaiter.Op.Name)
-	url = strings.ReplaceAll(url, "https://osconfig.googleapis.com/v1beta", "https://osconfig.googleapis.com/v1")
+	url = osConfigGAUrl(w.Config, url)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
 		Config:    w.Config,
@@ -52,7 +70,7 @@ func OSConfigOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitWithContext(config.Context, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -68,5 +86,5 @@ func OSConfigOperationWaitTime(config *transport_tpg.Config, op map[string]inter
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitWithContext(config.Context, w, activity, timeout, config.PollInterval)
 }
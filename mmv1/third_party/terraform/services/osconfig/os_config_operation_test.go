@@ -0,0 +1,45 @@
+package osconfig
+
+import (
+	"testing"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func TestOSConfigGAUrl(t *testing.T) {
+	cases := map[string]struct {
+		BasePath string
+		Url      string
+		Expected string
+	}{
+		"ga": {
+			BasePath: "https://osconfig.googleapis.com/v1/",
+			Url:      "https://osconfig.googleapis.com/v1/projects/my-project/locations/us-central1-a/osPolicyAssignments/my-assignment",
+			Expected: "https://osconfig.googleapis.com/v1/projects/my-project/locations/us-central1-a/osPolicyAssignments/my-assignment",
+		},
+		"beta": {
+			BasePath: "https://osconfig.googleapis.com/v1beta/",
+			Url:      "https://osconfig.googleapis.com/v1beta/projects/my-project/locations/us-central1-a/osPolicyAssignments/my-assignment",
+			Expected: "https://osconfig.googleapis.com/v1/projects/my-project/locations/us-central1-a/osPolicyAssignments/my-assignment",
+		},
+		"custom endpoint": {
+			BasePath: "https://osconfig.example.com/v1beta/",
+			Url:      "https://osconfig.example.com/v1beta/projects/my-project/locations/us-central1-a/osPolicyAssignments/my-assignment",
+			Expected: "https://osconfig.example.com/v1/projects/my-project/locations/us-central1-a/osPolicyAssignments/my-assignment",
+		},
+		"version in resource name": {
+			BasePath: "https://osconfig.googleapis.com/v1beta/",
+			Url:      "https://osconfig.googleapis.com/v1beta/projects/my-project/locations/us-central1-a/osPolicyAssignments/v1beta",
+			Expected: "https://osconfig.googleapis.com/v1/projects/my-project/locations/us-central1-a/osPolicyAssignments/v1beta",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			config := &transport_tpg.Config{OSConfigBasePath: tc.BasePath}
+			if got := osConfigGAUrl(config, tc.Url); got != tc.Expected {
+				t.Errorf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
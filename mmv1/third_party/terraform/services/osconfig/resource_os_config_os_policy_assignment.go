@@ -1116,7 +1116,7 @@ func resourceOSConfigOSPolicyAssignmentCreate(d *schema.ResourceData, meta inter
 		return err
 	}
 	// Always use GA endpoints for this resource.
-	url = strings.ReplaceAll(url, "https://osconfig.googleapis.com/v1beta", "https://osconfig.googleapis.com/v1")
+	url = osConfigGAUrl(config, url)
 	// Remove redundant projects/ from url.
 	url = strings.ReplaceAll(url, "projects/projects/", "projects/")
 
@@ -1201,7 +1201,7 @@ func resourceOSConfigOSPolicyAssignmentRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 	// Always use GA endpoints for this resource.
-	url = strings.ReplaceAll(url, "https://osconfig.googleapis.com/v1beta", "https://osconfig.googleapis.com/v1")
+	url = osConfigGAUrl(config, url)
 	// Remove redundant projects/ from url.
 	url = strings.ReplaceAll(url, "projects/projects/", "projects/")
 
@@ -1321,7 +1321,7 @@ func resourceOSConfigOSPolicyAssignmentUpdate(d *schema.ResourceData, meta inter
 		return err
 	}
 	// Always use GA endpoints for this resource.
-	url = strings.ReplaceAll(url, "https://osconfig.googleapis.com/v1beta", "https://osconfig.googleapis.com/v1")
+	url = osConfigGAUrl(config, url)
 	// Remove redundant projects/ from url.
 	url = strings.ReplaceAll(url, "projects/projects/", "projects/")
 
@@ -1405,7 +1405,7 @@ func resourceOSConfigOSPolicyAssignmentDelete(d *schema.ResourceData, meta inter
 		return err
 	}
 	// Always use GA endpoints for this resource.
-	url = strings.ReplaceAll(url, "https://osconfig.googleapis.com/v1beta", "https://osconfig.googleapis.com/v1")
+	url = osConfigGAUrl(config, url)
 	// Remove redundant projects/ from url.
 	url = strings.ReplaceAll(url, "projects/projects/", "projects/")
 
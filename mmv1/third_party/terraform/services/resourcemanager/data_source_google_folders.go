@@ -72,7 +72,7 @@ func dataSourceGoogleFoldersRead(d *schema.ResourceData, meta interface{}) error
 
 	for {
 		params["parent"] = d.Get("parent_id").(string)
-		url := "https://cloudresourcemanager.googleapis.com/v3/folders"
+		url := config.ResourceManagerV3BasePath + "folders"
 
 		url, err := transport_tpg.AddQueryParams(url, params)
 		if err != nil {
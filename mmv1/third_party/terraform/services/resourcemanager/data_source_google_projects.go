@@ -75,7 +75,7 @@ func datasourceGoogleProjectsRead(d *schema.ResourceData, meta interface{}) erro
 
 	for {
 		params["filter"] = d.Get("filter").(string)
-		url := "https://cloudresourcemanager.googleapis.com/v1/projects"
+		url := config.ResourceManagerBasePath + "projects"
 
 		url, err := transport_tpg.AddQueryParams(url, params)
 		if err != nil {
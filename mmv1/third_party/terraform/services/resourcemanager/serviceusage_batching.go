@@ -1,6 +1,7 @@
 package resourcemanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -19,6 +20,10 @@ const (
 // BatchRequestEnableServices can be used to batch requests to enable services
 // across resource nodes, i.e. to batch creation of several
 // google_project_service(s) resources.
+//
+// The request is dropped from its batch when the create timeout passes or the
+// provider is stopped. Resource CRUD functions aren't given a context of their
+// own, so those are the only cancellations that reach a waiting request.
 func BatchRequestEnableService(service string, project string, d *schema.ResourceData, config *transport_tpg.Config) error {
 	// Renamed service create calls are relatively likely to fail, so don't try to batch the call.
 	if altName, ok := renamedServicesByOldAndNewServiceNames[service]; ok {
@@ -44,10 +49,12 @@ func BatchRequestEnableService(service string, project string, d *schema.Resourc
 		DebugId:      fmt.Sprintf("Enable Project Service %q for project %q", service, project),
 	}
 
-	_, err = config.RequestBatcherServiceUsage.SendRequestWithTimeout(
+	ctx, cancel := context.WithTimeout(config.Context, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	_, err = config.RequestBatcherServiceUsage.SendRequestWithContext(
+		ctx,
 		fmt.Sprintf(batchKeyTmplServiceUsageEnableServices, project),
-		req,
-		d.Timeout(schema.TimeoutCreate))
+		req)
 	return err
 }
 
@@ -78,6 +85,9 @@ func tryEnableRenamedService(service, altName string, project string, d *schema.
 	return nil
 }
 
+// BatchRequestReadServices batches requests to list the services enabled on a
+// project. Like BatchRequestEnableService, a waiting request is only dropped
+// when the read timeout passes or the provider is stopped.
 func BatchRequestReadServices(project string, d *schema.ResourceData, config *transport_tpg.Config) (interface{}, error) {
 	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
 	if err != nil {
@@ -99,10 +109,12 @@ func BatchRequestReadServices(project string, d *schema.ResourceData, config *tr
 		DebugId:  fmt.Sprintf("List Project Services %s", project),
 	}
 
-	return config.RequestBatcherServiceUsage.SendRequestWithTimeout(
+	ctx, cancel := context.WithTimeout(config.Context, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+	return config.RequestBatcherServiceUsage.SendRequestWithContext(
+		ctx,
 		fmt.Sprintf(batchKeyTmplServiceUsageListServices, project),
-		req,
-		d.Timeout(schema.TimeoutRead))
+		req)
 }
 
 func combineServiceUsageServicesBatches(srvsRaw interface{}, toAddRaw interface{}) (interface{}, error) {
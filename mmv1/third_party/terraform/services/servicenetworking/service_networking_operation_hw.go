@@ -33,5 +33,5 @@ func ServiceNetworkingOperationWaitTimeHW(config *transport_tpg.Config, op *serv
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitWithContext(config.Context, w, activity, timeout, config.PollInterval)
 }
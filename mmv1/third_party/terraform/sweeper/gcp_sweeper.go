@@ -2,11 +2,15 @@ package sweeper
 
 import (
 	"encoding/hex"
+	"flag"
 	"fmt"
 	"hash/crc32"
 	"log"
+	"os"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
@@ -101,15 +105,173 @@ func ListParentResourcesInLocation(d *tpgresource.ResourceDataMock, config *tran
 	return names, nil
 }
 
+// DryRunEnvVar is the environment variable that, when set to "true", makes sweepers log the
+// resources they would delete instead of deleting them.
+const DryRunEnvVar = "SWEEPER_DRY_RUN"
+
+// SweeperOptions are the optional settings of a sweeper registered with AddTestSweepersWithOptions.
+type SweeperOptions struct {
+	// Names of the sweepers that must run before this sweeper, such as those of resources that
+	// must be deleted before the resources this sweeper deletes.
+	Dependencies []string
+	// Set by sweepers that check IsDryRun before deleting anything. Other sweepers are skipped
+	// during a dry run.
+	SupportsDryRun bool
+}
+
+type registeredSweeper struct {
+	name    string
+	options SweeperOptions
+}
+
+var (
+	// registeredSweepers are keyed by their unique name
+	registeredSweepers = make(map[string]registeredSweeper)
+	// printedPlans holds the regions the sweep plan has been printed for
+	printedPlans   = make(map[string]bool)
+	printedPlansMu sync.Mutex
+)
+
+// IsDryRun returns true if sweepers should log what they would delete instead of deleting it.
+func IsDryRun() bool {
+	return os.Getenv(DryRunEnvVar) == "true"
+}
+
 func AddTestSweepers(name string, sweeper func(region string) error) {
+	AddTestSweepersWithOptions(name, sweeper, SweeperOptions{})
+}
+
+// AddTestSweepersWithOptions registers a sweeper that runs after the sweepers it depends on. The
+// first sweeper that runs in a region prints the order in which all of the sweepers selected by
+// -sweep-run will run in that region.
+func AddTestSweepersWithOptions(name string, sweeper func(region string) error, opts SweeperOptions) {
+	uniqueName := uniqueSweeperName(name)
+	registeredSweepers[uniqueName] = registeredSweeper{name: name, options: opts}
+
+	var dependencies []string
+	for _, d := range opts.Dependencies {
+		dependencies = append(dependencies, uniqueSweeperName(d))
+	}
+
+	resource.AddTestSweepers(uniqueName, &resource.Sweeper{
+		Name:         name,
+		Dependencies: dependencies,
+		F: func(region string) error {
+			if err := printSweepPlan(region); err != nil {
+				return err
+			}
+			if IsDryRun() && !opts.SupportsDryRun {
+				log.Printf("[INFO][SWEEPER_LOG] Skipping sweeper %s during dry run, as it doesn't support dry runs", name)
+				return nil
+			}
+			return sweeper(region)
+		},
+	})
+}
+
+// uniqueSweeperName returns the name a sweeper is registered under. Dependencies must refer to
+// sweepers by this name.
+func uniqueSweeperName(name string) string {
 	_, filename, _, _ := runtime.Caller(0)
 	hash := crc32.NewIEEE()
 	hash.Write([]byte(filename))
 	hashedFilename := hex.EncodeToString(hash.Sum(nil))
-	uniqueName := name + "_" + hashedFilename
+	return name + "_" + hashedFilename
+}
 
-	resource.AddTestSweepers(uniqueName, &resource.Sweeper{
-		Name: name,
-		F:    sweeper,
-	})
+func printSweepPlan(region string) error {
+	printedPlansMu.Lock()
+	defer printedPlansMu.Unlock()
+	if printedPlans[region] {
+		return nil
+	}
+	printedPlans[region] = true
+
+	sweepRun := ""
+	if f := flag.Lookup("sweep-run"); f != nil {
+		sweepRun = f.Value.String()
+	}
+	plan, err := SweepPlan(sweepRun)
+	if err != nil {
+		return fmt.Errorf("error computing sweep plan for region %s: %w", region, err)
+	}
+	mode := ""
+	if IsDryRun() {
+		mode = " (dry run, nothing will be deleted)"
+	}
+	log.Printf("[INFO][SWEEPER_LOG] Sweep plan for region %s%s:", region, mode)
+	for i, name := range plan {
+		note := ""
+		if IsDryRun() && !registeredSweepers[uniqueSweeperName(name)].options.SupportsDryRun {
+			note = " (skipped, doesn't support dry runs)"
+		}
+		log.Printf("[INFO][SWEEPER_LOG]   %d. %s%s", i+1, name, note)
+	}
+	return nil
+}
+
+// SweepPlan returns the names of the registered sweepers selected by sweepRun, in the order they
+// run: every sweeper runs after its dependencies. sweepRun is the value of the -sweep-run flag, a
+// comma-separated list of case-insensitive substrings of sweeper names, or "" to select all
+// sweepers. Dependencies of selected sweepers are selected too.
+func SweepPlan(sweepRun string) ([]string, error) {
+	return sweepPlan(sweepRun, registeredSweepers)
+}
+
+func sweepPlan(sweepRun string, sweepers map[string]registeredSweeper) ([]string, error) {
+	var filters []string
+	if sweepRun != "" {
+		filters = strings.Split(strings.ToLower(sweepRun), ",")
+	}
+
+	var selected []string
+	for uniqueName := range sweepers {
+		if filters == nil {
+			selected = append(selected, uniqueName)
+			continue
+		}
+		for _, f := range filters {
+			if strings.Contains(strings.ToLower(uniqueName), f) {
+				selected = append(selected, uniqueName)
+				break
+			}
+		}
+	}
+	sort.Strings(selected)
+
+	var plan []string
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+	var visit func(uniqueName string) error
+	visit = func(uniqueName string) error {
+		if visited[uniqueName] {
+			return nil
+		}
+		s := sweepers[uniqueName]
+		if visiting[uniqueName] {
+			return fmt.Errorf("sweeper %s is part of a dependency cycle", s.name)
+		}
+		visiting[uniqueName] = true
+		deps := append([]string(nil), s.options.Dependencies...)
+		sort.Strings(deps)
+		for _, d := range deps {
+			// The test framework fails to run a sweeper with a dependency that isn't registered
+			if _, ok := sweepers[uniqueSweeperName(d)]; !ok {
+				return fmt.Errorf("sweeper %s depends on %s, which is not registered", s.name, d)
+			}
+			if err := visit(uniqueSweeperName(d)); err != nil {
+				return err
+			}
+		}
+		visiting[uniqueName] = false
+		visited[uniqueName] = true
+		plan = append(plan, s.name)
+		return nil
+	}
+	for _, uniqueName := range selected {
+		if err := visit(uniqueName); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
 }
@@ -0,0 +1,74 @@
+package sweeper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSweepPlan(t *testing.T) {
+	t.Parallel()
+
+	sweepers := make(map[string]registeredSweeper)
+	for name, deps := range map[string][]string{
+		"ComputeNetwork":      {"ComputeSubnetwork", "ComputeFirewall"},
+		"ComputeSubnetwork":   nil,
+		"ComputeFirewall":     nil,
+		"SQLDatabaseInstance": nil,
+	} {
+		sweepers[uniqueSweeperName(name)] = registeredSweeper{name: name, options: SweeperOptions{Dependencies: deps}}
+	}
+
+	cases := map[string]struct {
+		sweepRun string
+		expected []string
+	}{
+		"all sweepers": {
+			sweepRun: "",
+			expected: []string{"ComputeFirewall", "ComputeSubnetwork", "ComputeNetwork", "SQLDatabaseInstance"},
+		},
+		"dependencies of selected sweepers are included": {
+			sweepRun: "computenetwork",
+			expected: []string{"ComputeFirewall", "ComputeSubnetwork", "ComputeNetwork"},
+		},
+		"multiple filters": {
+			sweepRun: "ComputeSubnetwork,SQL",
+			expected: []string{"ComputeSubnetwork", "SQLDatabaseInstance"},
+		},
+	}
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			plan, err := sweepPlan(tc.sweepRun, sweepers)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(plan, tc.expected) {
+				t.Errorf("expected plan %v, got %v", tc.expected, plan)
+			}
+		})
+	}
+}
+
+func TestSweepPlan_cycle(t *testing.T) {
+	t.Parallel()
+
+	sweepers := map[string]registeredSweeper{
+		uniqueSweeperName("A"): {name: "A", options: SweeperOptions{Dependencies: []string{"B"}}},
+		uniqueSweeperName("B"): {name: "B", options: SweeperOptions{Dependencies: []string{"A"}}},
+	}
+	if _, err := sweepPlan("", sweepers); err == nil {
+		t.Error("expected an error for a dependency cycle")
+	}
+}
+
+func TestSweepPlan_unregisteredDependency(t *testing.T) {
+	t.Parallel()
+
+	sweepers := map[string]registeredSweeper{
+		uniqueSweeperName("A"): {name: "A", options: SweeperOptions{Dependencies: []string{"NotRegistered"}}},
+	}
+	if _, err := sweepPlan("", sweepers); err == nil {
+		t.Error("expected an error for a dependency that isn't registered")
+	}
+}
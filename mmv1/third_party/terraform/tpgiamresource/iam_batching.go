@@ -1,6 +1,7 @@
 package tpgiamresource
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,6 +13,10 @@ const (
 	batchKeyTmplModifyIamPolicy = "%s modifyIamPolicy"
 )
 
+// BatchRequestModifyIamPolicy batches modify with other changes to the same IAM
+// policy. The IAM resources' CRUD functions don't receive a context, so a waiting
+// request is only dropped after 30 minutes or when the provider is stopped
+// (config.Context), not when a single resource's operation is cancelled.
 func BatchRequestModifyIamPolicy(updater ResourceIamUpdater, modify iamPolicyModifyFunc, config *transport_tpg.Config, reqDesc string) error {
 	batchKey := fmt.Sprintf(batchKeyTmplModifyIamPolicy, updater.GetMutexKey())
 
@@ -23,7 +28,9 @@ func BatchRequestModifyIamPolicy(updater ResourceIamUpdater, modify iamPolicyMod
 		DebugId:      reqDesc,
 	}
 
-	_, err := config.RequestBatcherIam.SendRequestWithTimeout(batchKey, request, time.Minute*30)
+	ctx, cancel := context.WithTimeout(config.Context, time.Minute*30)
+	defer cancel()
+	_, err := config.RequestBatcherIam.SendRequestWithContext(ctx, batchKey, request)
 	return err
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
 func SetAnnotationsDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
@@ -13,20 +14,37 @@ func SetAnnotationsDiff(_ context.Context, d *schema.ResourceDiff, meta interfac
 		return nil
 	}
 
+	if d.Get("terraform_annotations") == nil {
+		return fmt.Errorf("`terraform_annotations` field is not present in the resource schema.")
+	}
+
 	if d.Get("effective_annotations") == nil {
 		return fmt.Errorf("`effective_annotations` field is not present in the resource schema.")
 	}
 
-	// If "annotations" field is computed, set "effective_annotations" to computed.
+	// If "annotations" field is computed, set "terraform_annotations" and "effective_annotations" to computed.
 	// https://github.com/hashicorp/terraform-provider-google/issues/16217
 	if !d.GetRawPlan().GetAttr("annotations").IsWhollyKnown() {
+		if err := d.SetNewComputed("terraform_annotations"); err != nil {
+			return fmt.Errorf("error setting terraform_annotations to computed: %w", err)
+		}
+
 		if err := d.SetNewComputed("effective_annotations"); err != nil {
 			return fmt.Errorf("error setting effective_annotations to computed: %w", err)
 		}
 		return nil
 	}
 
-	o, n := d.GetChange("annotations")
+	config := meta.(*transport_tpg.Config)
+
+	// Merge provider default annotations with the user defined annotations in the resource to get terraform managed annotations
+	if err := d.SetNew("terraform_annotations", mergeDefaultAnnotations(config, raw)); err != nil {
+		return fmt.Errorf("error setting new terraform_annotations diff: %w", err)
+	}
+
+	// Diff against the annotations Terraform managed before, including default
+	// annotations that have since been removed from the provider.
+	o, n := d.GetChange("terraform_annotations")
 	effectiveAnnotations := d.Get("effective_annotations").(map[string]interface{})
 
 	for k, v := range n.(map[string]interface{}) {
@@ -68,24 +86,34 @@ func SetMetadataAnnotationsDiff(_ context.Context, d *schema.ResourceDiff, meta
 		return nil
 	}
 
+	if d.Get("metadata.0.terraform_annotations") == nil {
+		return fmt.Errorf("`metadata.0.terraform_annotations` field is not present in the resource schema.")
+	}
+
 	if d.Get("metadata.0.effective_annotations") == nil {
 		return fmt.Errorf("`metadata.0.effective_annotations` field is not present in the resource schema.")
 	}
 
-	o, n := d.GetChange("metadata.0.annotations")
+	config := meta.(*transport_tpg.Config)
+
+	// Diff against the annotations Terraform managed before, including default
+	// annotations that have since been removed from the provider.
+	oldAnnotations, _ := d.GetChange("metadata.0.terraform_annotations")
+	newAnnotations := mergeDefaultAnnotations(config, raw)
 	effectiveAnnotations := d.Get("metadata.0.effective_annotations").(map[string]interface{})
 
-	for k, v := range n.(map[string]interface{}) {
-		effectiveAnnotations[k] = v.(string)
+	for k, v := range newAnnotations {
+		effectiveAnnotations[k] = v
 	}
 
-	for k := range o.(map[string]interface{}) {
-		if _, ok := n.(map[string]interface{})[k]; !ok {
+	for k := range oldAnnotations.(map[string]interface{}) {
+		if _, ok := newAnnotations[k]; !ok {
 			delete(effectiveAnnotations, k)
 		}
 	}
 
 	original := l[0].(map[string]interface{})
+	original["terraform_annotations"] = newAnnotations
 	original["effective_annotations"] = effectiveAnnotations
 
 	if err := d.SetNew("metadata", []interface{}{original}); err != nil {
@@ -95,7 +123,23 @@ func SetMetadataAnnotationsDiff(_ context.Context, d *schema.ResourceDiff, meta
 	return nil
 }
 
-// Sets the "annotations" field with the value of the field "effective_annotations" for data sources.
+// Merges provider default annotations with the user defined annotations in the resource
+// to get the annotations managed by Terraform. The user defined annotations override
+// the default annotations with the same keys.
+func mergeDefaultAnnotations(config *transport_tpg.Config, annotations interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range config.DefaultAnnotations {
+		merged[k] = v
+	}
+	if m, ok := annotations.(map[string]interface{}); ok {
+		for k, v := range m {
+			merged[k] = v.(string)
+		}
+	}
+	return merged
+}
+
+// Sets the "annotations" field and "terraform_annotations" with the value of the field "effective_annotations" for data sources.
 // When reading data source, as the annotations field is unavailable in the configuration of the data source,
 // the "annotations" field will be empty. With this function, the labels "annotations" will have all of annotations in the resource.
 func SetDataSourceAnnotations(d *schema.ResourceData) error {
@@ -111,5 +155,12 @@ func SetDataSourceAnnotations(d *schema.ResourceData) error {
 		return fmt.Errorf("Error setting annotations in data source: %s", err)
 	}
 
+	if d.Get("terraform_annotations") == nil {
+		return fmt.Errorf("`terraform_annotations` field is not present in the resource schema.")
+	}
+	if err := d.Set("terraform_annotations", effectiveAnnotations); err != nil {
+		return fmt.Errorf("Error setting terraform_annotations in data source: %s", err)
+	}
+
 	return nil
 }
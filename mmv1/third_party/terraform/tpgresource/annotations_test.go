@@ -0,0 +1,38 @@
+package tpgresource
+
+import (
+	"reflect"
+	"testing"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func TestMergeDefaultAnnotations(t *testing.T) {
+	config := &transport_tpg.Config{
+		DefaultAnnotations: map[string]string{
+			"team":  "platform",
+			"owner": "default-owner",
+		},
+	}
+	annotations := map[string]interface{}{
+		"owner": "resource-owner",
+		"tier":  "gold",
+	}
+
+	expected := map[string]interface{}{
+		"team":  "platform",
+		"owner": "resource-owner",
+		"tier":  "gold",
+	}
+	if got := mergeDefaultAnnotations(config, annotations); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	expected = map[string]interface{}{
+		"team":  "platform",
+		"owner": "default-owner",
+	}
+	if got := mergeDefaultAnnotations(config, nil); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected default annotations %v, got %v", expected, got)
+	}
+}
@@ -1,12 +1,16 @@
 package tpgresource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 )
 
@@ -16,7 +20,7 @@ type CommonOpError struct {
 }
 
 func (e *CommonOpError) Error() string {
-	return fmt.Sprintf("Error code %v, message: %s", e.Code, e.Message)
+	return fmt.Sprintf("Error code %v, message: %s%s", e.Code, e.Message, FormatStatusDetails(e.Details))
 }
 
 type Waiter interface {
@@ -52,6 +56,13 @@ type Waiter interface {
 	TargetStates() []string
 }
 
+// ProgressWaiter is implemented by Waiters whose operations may report their
+// progress while they're running.
+type ProgressWaiter interface {
+	// Progress returns the operation's progress, or "" if it isn't reported.
+	Progress() string
+}
+
 type CommonOperationWaiter struct {
 	Op CommonOperation
 }
@@ -90,6 +101,14 @@ func (w *CommonOperationWaiter) OpName() string {
 	return w.Op.Name
 }
 
+func (w *CommonOperationWaiter) Progress() string {
+	if w == nil {
+		return ""
+	}
+
+	return OperationProgress(w.Op.Metadata)
+}
+
 func (w *CommonOperationWaiter) PendingStates() []string {
 	return []string{"done: false"}
 }
@@ -107,7 +126,12 @@ func OperationDone(w Waiter) bool {
 	return false
 }
 
+// CommonRefreshFunc polls w's operation. If w is a ProgressWaiter, each change in
+// its progress is logged as a warning. It can't be a diagnostic: operation waits
+// only return an error, and most resources' CRUD functions can't return warnings.
+// Logging at WARN makes it visible without the INFO and DEBUG noise of TF_LOG=INFO.
 func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
+	lastProgress := ""
 	return func() (interface{}, string, error) {
 		op, err := w.QueryOp()
 		if err != nil {
@@ -132,19 +156,74 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 		}
 
 		log.Printf("[DEBUG] Got %v while polling for operation %s's status", w.State(), w.OpName())
+		if pw, ok := w.(ProgressWaiter); ok {
+			if progress := pw.Progress(); progress != "" && progress != lastProgress {
+				log.Printf("[WARN] Operation %s progress: %s", w.OpName(), progress)
+				lastProgress = progress
+			}
+		}
 		return op, w.State(), nil
 	}
 }
 
+// tracedRefreshFunc wraps refresh, which polls w, so that each poll is recorded
+// in a span that's a child of the span in ctx.
+func tracedRefreshFunc(ctx context.Context, w Waiter, refresh retry.StateRefreshFunc) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		_, span := transport_tpg.Tracer().Start(ctx, "OperationPoll",
+			trace.WithAttributes(attribute.String("gcp.operation.name", w.OpName())),
+		)
+		defer span.End()
+
+		op, state, err := refresh()
+		span.SetAttributes(attribute.String("gcp.operation.state", state))
+		if pw, ok := w.(ProgressWaiter); ok {
+			if progress := pw.Progress(); progress != "" {
+				span.SetAttributes(attribute.String("gcp.operation.progress", progress))
+			}
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return op, state, err
+	}
+}
+
 func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
+	return OperationWaitWithContext(context.Background(), w, activity, timeout, pollInterval)
+}
+
+// OperationWaitWithContext is OperationWait, recording the wait and each poll
+// of the operation in spans that are children of the span in ctx, if any, when
+// OpenTelemetry tracing is enabled.
+func OperationWaitWithContext(ctx context.Context, w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) (err error) {
 	if OperationDone(w) {
 		return w.Error()
 	}
 
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := transport_tpg.Tracer().Start(ctx, "OperationWait",
+		trace.WithAttributes(
+			attribute.String("gcp.operation.name", w.OpName()),
+			attribute.String("terraform.activity", activity),
+		),
+	)
+	defer func() {
+		span.SetAttributes(attribute.String("gcp.operation.state", w.State()))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	c := &retry.StateChangeConf{
 		Pending:      w.PendingStates(),
 		Target:       w.TargetStates(),
-		Refresh:      CommonRefreshFunc(w),
+		Refresh:      tracedRefreshFunc(ctx, w, CommonRefreshFunc(w)),
 		Timeout:      timeout,
 		MinTimeout:   2 * time.Second,
 		PollInterval: pollInterval,
@@ -0,0 +1,152 @@
+package tpgresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// statusDetail holds the fields of the google.rpc error details an operation's
+// google.rpc.Status may include that are useful to show to users.
+type statusDetail struct {
+	Type string `json:"@type"`
+
+	// google.rpc.ErrorInfo
+	Reason   string            `json:"reason"`
+	Domain   string            `json:"domain"`
+	Metadata map[string]string `json:"metadata"`
+
+	// google.rpc.QuotaFailure and google.rpc.PreconditionFailure
+	Violations []struct {
+		Type        string `json:"type"`
+		Subject     string `json:"subject"`
+		Description string `json:"description"`
+	} `json:"violations"`
+
+	// google.rpc.BadRequest
+	FieldViolations []struct {
+		Field       string `json:"field"`
+		Description string `json:"description"`
+	} `json:"fieldViolations"`
+
+	// google.rpc.Help
+	Links []struct {
+		Description string `json:"description"`
+		Url         string `json:"url"`
+	} `json:"links"`
+
+	// google.rpc.LocalizedMessage
+	Message string `json:"message"`
+}
+
+// FormatStatusDetails returns the details of a google.rpc.Status as readable
+// lines, one per ErrorInfo, violation or link, to append to the status'
+// message. Details of unknown types are shown as JSON.
+func FormatStatusDetails(details []googleapi.RawMessage) string {
+	var lines []string
+	for _, raw := range details {
+		var detail statusDetail
+		if err := json.Unmarshal(raw, &detail); err != nil {
+			lines = append(lines, string(raw))
+			continue
+		}
+
+		typeName := detail.Type[strings.LastIndex(detail.Type, ".")+1:]
+		switch typeName {
+		case "ErrorInfo":
+			line := fmt.Sprintf("ErrorInfo: reason %s", detail.Reason)
+			if detail.Domain != "" {
+				line += fmt.Sprintf(" (domain %s)", detail.Domain)
+			}
+			if len(detail.Metadata) > 0 {
+				keys := make([]string, 0, len(detail.Metadata))
+				for k := range detail.Metadata {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for i, k := range keys {
+					keys[i] = k + "=" + detail.Metadata[k]
+				}
+				line += ", metadata: " + strings.Join(keys, ", ")
+			}
+			lines = append(lines, line)
+		case "QuotaFailure", "PreconditionFailure":
+			for _, v := range detail.Violations {
+				subject := v.Subject
+				if v.Type != "" {
+					subject = v.Type + " " + subject
+				}
+				lines = append(lines, fmt.Sprintf("%s: %s: %s", typeName, strings.TrimSpace(subject), v.Description))
+			}
+		case "BadRequest":
+			for _, v := range detail.FieldViolations {
+				lines = append(lines, fmt.Sprintf("BadRequest: field %s: %s", v.Field, v.Description))
+			}
+		case "Help":
+			for _, l := range detail.Links {
+				if l.Description == "" {
+					lines = append(lines, fmt.Sprintf("Help: %s", l.Url))
+					continue
+				}
+				lines = append(lines, fmt.Sprintf("Help: %s: %s", l.Description, l.Url))
+			}
+		case "LocalizedMessage":
+			lines = append(lines, fmt.Sprintf("LocalizedMessage: %s", detail.Message))
+		default:
+			lines = append(lines, string(raw))
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\nDetails:\n- " + strings.Join(lines, "\n- ")
+}
+
+// Fields of operation metadata that APIs report an operation's progress in,
+// in order of preference.
+var (
+	operationProgressPercentFields = []string{"progressPercent", "progressPercentage", "percentComplete", "progress"}
+	operationProgressStageFields   = []string{"statusDetail", "statusMessage", "stage", "currentStage"}
+)
+
+// OperationProgress returns the progress an operation's metadata reports, as
+// a percentage and/or a stage, e.g. "40% (Provisioning)", or "" if the API
+// doesn't report progress.
+func OperationProgress(metadata googleapi.RawMessage) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(metadata, &m); err != nil {
+		return ""
+	}
+
+	percent := ""
+	for _, f := range operationProgressPercentFields {
+		if v, ok := m[f].(float64); ok && v >= 0 && v <= 100 {
+			percent = fmt.Sprintf("%d%%", int(math.Round(v)))
+			break
+		}
+	}
+	stage := ""
+	for _, f := range operationProgressStageFields {
+		if v, ok := m[f].(string); ok && v != "" {
+			stage = v
+			break
+		}
+	}
+
+	switch {
+	case percent != "" && stage != "":
+		return fmt.Sprintf("%s (%s)", percent, stage)
+	case percent != "":
+		return percent
+	default:
+		return stage
+	}
+}
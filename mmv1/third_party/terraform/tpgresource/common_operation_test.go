@@ -6,6 +6,8 @@ import (
 	"time"
 
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
 )
 
 type TestWaiter struct {
@@ -67,3 +69,70 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+func TestCommonOpError_Details(t *testing.T) {
+	err := &CommonOpError{&cloudresourcemanager.Status{
+		Code:    8,
+		Message: "Quota exceeded for quota metric 'Create requests'.",
+		Details: []googleapi.RawMessage{
+			googleapi.RawMessage(`{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "RATE_LIMIT_EXCEEDED", "domain": "googleapis.com", "metadata": {"service": "example.googleapis.com", "quota_metric": "example.googleapis.com/create_requests"}}`),
+			googleapi.RawMessage(`{"@type": "type.googleapis.com/google.rpc.QuotaFailure", "violations": [{"subject": "project:123", "description": "Limit of 10 per minute exceeded"}]}`),
+			googleapi.RawMessage(`{"@type": "type.googleapis.com/google.rpc.BadRequest", "fieldViolations": [{"field": "widget.size", "description": "must be positive"}]}`),
+			googleapi.RawMessage(`{"@type": "type.googleapis.com/google.rpc.Help", "links": [{"description": "Request a higher quota limit.", "url": "https://cloud.google.com/docs/quotas/help/request_increase"}]}`),
+			googleapi.RawMessage(`{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "30s"}`),
+		},
+	}}
+
+	expected := `Error code 8, message: Quota exceeded for quota metric 'Create requests'.
+Details:
+- ErrorInfo: reason RATE_LIMIT_EXCEEDED (domain googleapis.com), metadata: quota_metric=example.googleapis.com/create_requests, service=example.googleapis.com
+- QuotaFailure: project:123: Limit of 10 per minute exceeded
+- BadRequest: field widget.size: must be positive
+- Help: Request a higher quota limit.: https://cloud.google.com/docs/quotas/help/request_increase
+- {"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "30s"}`
+	if got := err.Error(); got != expected {
+		t.Errorf("expected error:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCommonOpError_NoDetails(t *testing.T) {
+	err := &CommonOpError{&cloudresourcemanager.Status{Code: 13, Message: "internal error"}}
+	if got, expected := err.Error(), "Error code 13, message: internal error"; got != expected {
+		t.Errorf("expected error %q, got %q", expected, got)
+	}
+}
+
+func TestOperationProgress(t *testing.T) {
+	cases := map[string]struct {
+		Metadata string
+		Expected string
+	}{
+		"no metadata": {},
+		"no progress": {
+			Metadata: `{"@type": "type.googleapis.com/google.cloud.example.v1.OperationMetadata", "target": "projects/p/widgets/w", "verb": "create"}`,
+		},
+		"percent": {
+			Metadata: `{"progressPercent": 40}`,
+			Expected: "40%",
+		},
+		"stage": {
+			Metadata: `{"statusDetail": "Provisioning"}`,
+			Expected: "Provisioning",
+		},
+		"percent and stage": {
+			Metadata: `{"progressPercentage": 12.6, "stage": "Creating nodes"}`,
+			Expected: "13% (Creating nodes)",
+		},
+		"progress object": {
+			Metadata: `{"progress": {"workCompleted": 5}}`,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := OperationProgress(googleapi.RawMessage(tc.Metadata)); got != tc.Expected {
+				t.Errorf("expected progress %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
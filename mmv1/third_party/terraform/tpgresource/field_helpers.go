@@ -323,6 +323,10 @@ func GetBillingProjectFromSchema(billingProjectSchemaField string, d TerraformRe
 	if ok && billingProjectSchemaField != "" {
 		return res.(string), nil
 	}
+	// Requests routed to the resource's own project aren't attributed to the provider's billing project
+	if config.QuotaProjectRouting != nil && config.QuotaProjectRouting.ResourceProject {
+		return "", fmt.Errorf("%s: routed to the resource's project by quota_project_routing", billingProjectSchemaField)
+	}
 	if config.BillingProject != "" {
 		return config.BillingProject, nil
 	}
@@ -132,6 +132,101 @@ func setLabelsFields(labelsField string, d *schema.ResourceDiff, meta interface{
 	return nil
 }
 
+// SetIgnoredLabelsDiff is the CustomizeDiff func that leaves the labels ignored
+// by the provider's ignore_labels out of the planned "effective_labels", unless
+// they're in "terraform_labels". It runs after SetLabelsDiff, in resources that
+// leave ignored labels out of "effective_labels" when they're read, with
+// FlattenEffectiveLabels, and keep them when labels are updated, with
+// MergeIgnoredLabels.
+func SetIgnoredLabelsDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := meta.(*transport_tpg.Config)
+	if config.IgnoreLabels.IsEmpty() || !d.GetRawPlan().GetAttr("labels").IsWhollyKnown() {
+		return nil
+	}
+
+	terraformLabels, _ := d.Get("terraform_labels").(map[string]interface{})
+	effectiveLabels, _ := d.Get("effective_labels").(map[string]interface{})
+	ignored := false
+	for k := range effectiveLabels {
+		if _, ok := terraformLabels[k]; !ok && config.IgnoreLabels.Ignores(k) {
+			delete(effectiveLabels, k)
+			ignored = true
+		}
+	}
+	if !ignored {
+		return nil
+	}
+
+	if err := d.SetNew("effective_labels", effectiveLabels); err != nil {
+		return fmt.Errorf("error setting new effective_labels diff: %w", err)
+	}
+	return nil
+}
+
+// FlattenEffectiveLabels is called in the READ method of the resources to
+// flatten the field "effective_labels" from v, all of labels returned from the
+// API. The labels ignored by the provider's ignore_labels are left out, unless
+// they're in "terraform_labels", so that labels added by other systems aren't
+// reported as changes.
+func FlattenEffectiveLabels(v interface{}, d TerraformResourceData, config *transport_tpg.Config) interface{} {
+	labels, ok := v.(map[string]interface{})
+	if !ok || config == nil || config.IgnoreLabels.IsEmpty() {
+		return v
+	}
+
+	terraformLabels, _ := d.Get("terraform_labels").(map[string]interface{})
+	transformed := make(map[string]interface{})
+	for k, val := range labels {
+		if _, ok := terraformLabels[k]; ok || !config.IgnoreLabels.Ignores(k) {
+			transformed[k] = val
+		}
+	}
+	return transformed
+}
+
+// MergeIgnoredLabels is called when labels, the expanded "effective_labels"
+// field, are sent to the API to update a resource. As the labels ignored by the
+// provider's ignore_labels aren't in the state, their current values are read
+// from the resource at url, in its field, and added to labels so that the
+// update doesn't remove them. Nothing is read when no labels are ignored.
+func MergeIgnoredLabels(d TerraformResourceData, config *transport_tpg.Config, labels map[string]string, url, field string) (map[string]string, error) {
+	if config == nil || config.IgnoreLabels.IsEmpty() || d.Id() == "" {
+		return labels, nil
+	}
+
+	url, err := ReplaceVars(d, config, url)
+	if err != nil {
+		return nil, err
+	}
+	userAgent, err := GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	billingProject := ""
+	if bp, err := GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Config:    config,
+		Method:    "GET",
+		Project:   billingProject,
+		RawURL:    url,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading labels of %q to preserve ignored labels: %s", d.Id(), err)
+	}
+
+	current, _ := res[field].(map[string]interface{})
+	for k, v := range current {
+		if _, ok := labels[k]; !ok && config.IgnoreLabels.Ignores(k) {
+			labels[k] = v.(string)
+		}
+	}
+	return labels, nil
+}
+
 func SetLabelsDiffWithoutAttributionLabel(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
 	return setLabelsFields("labels", d, meta, true)
 }
@@ -0,0 +1,85 @@
+package tpgresource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// PendingOperationField is the computed field resumable resources record an
+// operation in when Terraform stops waiting on it before it finishes, so that
+// the next run resumes waiting on it instead of sending a new request.
+//
+// The SDK doesn't let resources write their private state, so like the
+// `operation` field of google_compute_instance_group_manager the operation is
+// kept in the resource's state. Its value is the action that started the
+// operation followed by the operation as returned by the API, e.g.
+// `create:{"name":"operations/123"}`. The operation is empty for resources
+// that poll for their state rather than an operation.
+const PendingOperationField = "pending_operation"
+
+// PersistPendingOperation records op, the operation started by action, in the
+// resource's pending operation field if waiting on it failed with err because
+// Terraform was interrupted or the wait timed out, as the operation may still
+// finish. op is the operation returned by the API, or nil for resources that
+// poll for their state.
+//
+// It returns whether the operation was recorded and the error the caller
+// should return: nil if Terraform was interrupted while creating or updating
+// the resource, so that it is saved to state without being tainted, and
+// otherwise err, so that a resource that may not be deleted yet is kept.
+func PersistPendingOperation(d TerraformResourceData, config *transport_tpg.Config, action string, op map[string]interface{}, err error) (bool, error) {
+	interrupted := config.Context != nil && config.Context.Err() != nil
+	var timeoutErr *retry.TimeoutError
+	if !interrupted && !errors.As(err, &timeoutErr) {
+		return false, err
+	}
+
+	opJson := ""
+	if op != nil {
+		b, jsonErr := json.Marshal(op)
+		if jsonErr != nil {
+			return false, err
+		}
+		opJson = string(b)
+	}
+	log.Printf("[DEBUG] Persisting pending %s operation %s for %q so it can be resumed: %s", action, opJson, d.Id(), err)
+	if setErr := d.Set(PendingOperationField, action+":"+opJson); setErr != nil {
+		return true, fmt.Errorf("Error setting %s: %s", PendingOperationField, setErr)
+	}
+	if interrupted && action != "delete" {
+		return true, nil
+	}
+	return true, err
+}
+
+// GetPendingOperation returns the action and operation recorded by
+// PersistPendingOperation, if any.
+func GetPendingOperation(d TerraformResourceData) (action string, op map[string]interface{}, ok bool) {
+	v, _ := d.Get(PendingOperationField).(string)
+	if v == "" {
+		return "", nil, false
+	}
+	action, opJson, _ := strings.Cut(v, ":")
+	if opJson != "" {
+		if err := json.Unmarshal([]byte(opJson), &op); err != nil {
+			log.Printf("[WARN] Ignoring invalid pending %s operation %q for %q: %s", action, opJson, d.Id(), err)
+			return "", nil, false
+		}
+	}
+	return action, op, true
+}
+
+// ClearPendingOperation removes the operation recorded by
+// PersistPendingOperation, once it is resumed.
+func ClearPendingOperation(d TerraformResourceData) error {
+	if err := d.Set(PendingOperationField, ""); err != nil {
+		return fmt.Errorf("Error unsetting %s: %s", PendingOperationField, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package tpgresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func TestPersistPendingOperation(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	op := map[string]interface{}{"name": "operations/123"}
+	timeoutErr := fmt.Errorf("Error waiting for Creating Widget: %w", &retry.TimeoutError{})
+	otherErr := errors.New("operation failed")
+
+	cases := map[string]struct {
+		Context       context.Context
+		Action        string
+		Op            map[string]interface{}
+		Err           error
+		ExpectPending string
+		ExpectErr     error
+	}{
+		"operation error": {
+			Context:   context.Background(),
+			Action:    "create",
+			Op:        op,
+			Err:       otherErr,
+			ExpectErr: otherErr,
+		},
+		"timed out": {
+			Context:       context.Background(),
+			Action:        "create",
+			Op:            op,
+			Err:           timeoutErr,
+			ExpectPending: `create:{"name":"operations/123"}`,
+			ExpectErr:     timeoutErr,
+		},
+		"interrupted create": {
+			Context:       cancelled,
+			Action:        "create",
+			Op:            op,
+			Err:           otherErr,
+			ExpectPending: `create:{"name":"operations/123"}`,
+		},
+		"interrupted delete": {
+			Context:       cancelled,
+			Action:        "delete",
+			Op:            op,
+			Err:           otherErr,
+			ExpectPending: `delete:{"name":"operations/123"}`,
+			ExpectErr:     otherErr,
+		},
+		"interrupted poll": {
+			Context:       cancelled,
+			Action:        "create",
+			Err:           otherErr,
+			ExpectPending: "create:",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := &ResourceDataMock{FieldsInSchema: map[string]interface{}{}}
+			config := &transport_tpg.Config{Context: tc.Context}
+
+			persisted, err := PersistPendingOperation(d, config, tc.Action, tc.Op, tc.Err)
+			if persisted != (tc.ExpectPending != "") {
+				t.Errorf("expected persisted to be %t, got %t", tc.ExpectPending != "", persisted)
+			}
+			if err != tc.ExpectErr {
+				t.Errorf("expected error %v, got %v", tc.ExpectErr, err)
+			}
+			if got := d.Get(PendingOperationField); tc.ExpectPending != "" && got != tc.ExpectPending {
+				t.Errorf("expected pending operation %q, got %q", tc.ExpectPending, got)
+			}
+		})
+	}
+}
+
+func TestGetPendingOperation(t *testing.T) {
+	d := &ResourceDataMock{FieldsInSchema: map[string]interface{}{}}
+	if _, _, ok := GetPendingOperation(d); ok {
+		t.Fatalf("expected no pending operation")
+	}
+
+	if err := d.Set(PendingOperationField, `delete:{"name":"operations/123"}`); err != nil {
+		t.Fatal(err)
+	}
+	action, op, ok := GetPendingOperation(d)
+	if !ok || action != "delete" || op["name"] != "operations/123" {
+		t.Errorf("expected pending delete of operations/123, got (%q, %v, %t)", action, op, ok)
+	}
+
+	if err := d.Set(PendingOperationField, "create:"); err != nil {
+		t.Fatal(err)
+	}
+	action, op, ok = GetPendingOperation(d)
+	if !ok || action != "create" || op != nil {
+		t.Errorf("expected pending create without an operation, got (%q, %v, %t)", action, op, ok)
+	}
+
+	if err := ClearPendingOperation(d); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := GetPendingOperation(d); ok {
+		t.Errorf("expected pending operation to be cleared")
+	}
+}
@@ -0,0 +1,245 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// auditLogMaxBodyBytes caps how much of a response is read to find the name of
+// the operation it returns, so that large downloads aren't buffered.
+const auditLogMaxBodyBytes = 1 << 20
+
+// AuditLogEntry is a line of the audit log, describing one API call.
+type AuditLogEntry struct {
+	Time time.Time `json:"time"`
+	// Resource is the type of the Terraform resource the call was made for, if
+	// known.
+	Resource    string `json:"resource,omitempty"`
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	URLTemplate string `json:"url_template"`
+	// Status is the status code of the response, or 0 if no response was
+	// received.
+	Status    int    `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+	// Retries is the number of times the call was retried by the retry
+	// transport.
+	Retries      int    `json:"retries"`
+	Operation    string `json:"operation,omitempty"`
+	QuotaProject string `json:"quota_project,omitempty"`
+}
+
+// auditLogTransport is a http.RoundTripper that writes an AuditLogEntry as a
+// line of JSON for every request, so that API usage can be analysed after an
+// apply, e.g. to find which resources make the most calls or are throttled.
+type auditLogTransport struct {
+	internal http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewTransportWithAuditLog constructs a transport that writes an audit log of
+// requests to w.
+func NewTransportWithAuditLog(t http.RoundTripper, w io.Writer) *auditLogTransport {
+	return &auditLogTransport{
+		internal: t,
+		w:        w,
+	}
+}
+
+// auditLogFiles are the audit log files opened by OpenAuditLog, by path. They
+// stay open until the provider process exits.
+var auditLogFiles = struct {
+	sync.Mutex
+	files map[string]*os.File
+}{files: make(map[string]*os.File)}
+
+// OpenAuditLog opens the audit log file at path for appending, creating it if
+// needed. The file is opened once per process and shared by the provider
+// instances that log to it, as the provider is configured again for each
+// Terraform operation. Each instance appends whole lines in a single write, so
+// several instances can share a file.
+func OpenAuditLog(path string) (io.Writer, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	auditLogFiles.Lock()
+	defer auditLogFiles.Unlock()
+	if f, ok := auditLogFiles.files[path]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log %q: %w", path, err)
+	}
+	auditLogFiles.files[path] = f
+	return f, nil
+}
+
+// RoundTrip implements the RoundTripper interface method. It sends the request
+// and writes an audit log entry for it.
+func (t *auditLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	record := &auditRecord{}
+	req = req.WithContext(context.WithValue(req.Context(), auditRecordContextKey{}, record))
+
+	u := *req.URL
+	u.RawQuery = ""
+	entry := AuditLogEntry{
+		Time:         time.Now().UTC(),
+		Resource:     AuditResourceFromContext(req.Context()),
+		Method:       req.Method,
+		URL:          u.String(),
+		URLTemplate:  AuditURLTemplate(req.URL.Path),
+		QuotaProject: req.Header.Get("X-Goog-User-Project"),
+	}
+
+	resp, err := t.internal.RoundTrip(req)
+
+	entry.LatencyMs = time.Since(entry.Time).Milliseconds()
+	if record.attempts > 1 {
+		entry.Retries = record.attempts - 1
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.Operation = responseOperationName(resp)
+	}
+	t.write(entry)
+
+	return resp, err
+}
+
+func (t *auditLogTransport) write(entry AuditLogEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] Audit Log Transport: Unable to encode audit log entry: %s", err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.w.Write(append(b, '\n')); err != nil {
+		log.Printf("[WARN] Audit Log Transport: Unable to write audit log entry: %s", err)
+	}
+}
+
+// auditRecord collects details of a request from the transports it passes
+// through.
+type auditRecord struct {
+	attempts int
+}
+
+type auditRecordContextKey struct{}
+
+// recordAuditAttempt counts an attempt at sending the request with ctx, for
+// the audit log.
+func recordAuditAttempt(ctx context.Context) {
+	if record, ok := ctx.Value(auditRecordContextKey{}).(*auditRecord); ok {
+		record.attempts++
+	}
+}
+
+type auditResourceContextKey struct{}
+
+// ContextWithAuditResource returns a copy of ctx that makes the audit log
+// attribute requests sent with it to resource, a Terraform resource type.
+func ContextWithAuditResource(ctx context.Context, resource string) context.Context {
+	if resource == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, auditResourceContextKey{}, resource)
+}
+
+// AuditResourceFromContext returns the resource set by
+// ContextWithAuditResource, if any.
+func AuditResourceFromContext(ctx context.Context) string {
+	resource, _ := ctx.Value(auditResourceContextKey{}).(string)
+	return resource
+}
+
+var apiVersionRegex = regexp.MustCompile(`^v\d+[a-z0-9]*$`)
+
+// auditURLSingletons are path segments that aren't followed by an ID.
+var auditURLSingletons = map[string]bool{
+	"global":     true,
+	"aggregated": true,
+}
+
+// AuditURLTemplate returns path with the IDs of the resources it names
+// replaced by "{}", so that calls to the same API method can be grouped, e.g.
+// "/v1/projects/{}/locations/{}/widgets/{}:getIamPolicy". Paths follow the
+// collection/ID pattern of resource names after their API version.
+func AuditURLTemplate(path string) string {
+	parts := strings.Split(path, "/")
+	version := -1
+	for i, p := range parts {
+		if apiVersionRegex.MatchString(p) {
+			version = i
+			break
+		}
+	}
+	if version == -1 {
+		return path
+	}
+
+	isID := false
+	for i := version + 1; i < len(parts); i++ {
+		p := parts[i]
+		if !isID {
+			isID = !auditURLSingletons[p]
+			continue
+		}
+		if name, method, ok := strings.Cut(p, ":"); ok && name != "" {
+			parts[i] = "{}:" + method
+		} else if p != "" {
+			parts[i] = "{}"
+		}
+		isID = false
+	}
+	return strings.Join(parts, "/")
+}
+
+// responseOperationName returns the name of the long-running operation resp
+// returns, if any, leaving resp's body unread.
+func responseOperationName(resp *http.Response) string {
+	if resp.Body == nil || !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return ""
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, auditLogMaxBodyBytes))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+	if err != nil || len(b) == auditLogMaxBodyBytes {
+		return ""
+	}
+
+	var op struct {
+		Name string `json:"name"`
+		Kind string `json:"kind"`
+		Done *bool  `json:"done"`
+	}
+	if err := json.Unmarshal(b, &op); err != nil {
+		return ""
+	}
+	if op.Done != nil || strings.HasSuffix(op.Kind, "#operation") || strings.Contains(op.Name, "operations/") {
+		return op.Name
+	}
+	return ""
+}
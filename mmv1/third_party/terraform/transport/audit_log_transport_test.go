@@ -0,0 +1,120 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLogTransport(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Write([]byte(`{"name": "projects/my-project/locations/us-central1/operations/op-123", "done": false}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := ts.Client()
+	client.Transport = NewTransportWithAuditLog(&retryTransport{
+		internal:        http.DefaultTransport,
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		policy: &RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			Multiplier:     1,
+		},
+	}, &buf)
+
+	req, err := http.NewRequest("POST", ts.URL+"/v1/projects/my-project/locations/us-central1/widgets?widgetId=my-widget", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Goog-User-Project", "billing-project")
+	req = req.WithContext(ContextWithAuditResource(req.Context(), "google_widget"))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || !strings.Contains(string(body), "op-123") {
+		t.Fatalf("expected response body to be readable, got %q (%v)", body, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 audit log line, got %d: %q", len(lines), buf.String())
+	}
+	var entry AuditLogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected audit log line to be JSON: %v", err)
+	}
+	expected := AuditLogEntry{
+		Time:         entry.Time,
+		Resource:     "google_widget",
+		Method:       "POST",
+		URL:          ts.URL + "/v1/projects/my-project/locations/us-central1/widgets",
+		URLTemplate:  "/v1/projects/{}/locations/{}/widgets",
+		Status:       http.StatusOK,
+		LatencyMs:    entry.LatencyMs,
+		Retries:      1,
+		Operation:    "projects/my-project/locations/us-central1/operations/op-123",
+		QuotaProject: "billing-project",
+	}
+	if entry != expected {
+		t.Errorf("expected audit log entry %+v, got %+v", expected, entry)
+	}
+}
+
+func TestOpenAuditLog_reusesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	first, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("expected the audit log to be opened once")
+	}
+	t.Cleanup(func() {
+		auditLogFiles.Lock()
+		defer auditLogFiles.Unlock()
+		first.(*os.File).Close()
+		delete(auditLogFiles.files, path)
+	})
+}
+
+func TestAuditURLTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/v1/projects/my-project/locations/us-central1/widgets/my-widget":              "/v1/projects/{}/locations/{}/widgets/{}",
+		"/v1/projects/my-project/locations/us-central1/widgets/my-widget:setIamPolicy": "/v1/projects/{}/locations/{}/widgets/{}:setIamPolicy",
+		"/v1beta1/projects/my-project/widgets":                                         "/v1beta1/projects/{}/widgets",
+		"/compute/v1/projects/my-project/global/networks/my-network":                   "/compute/v1/projects/{}/global/networks/{}",
+		"/compute/v1/projects/my-project/aggregated/instances":                         "/compute/v1/projects/{}/aggregated/instances",
+		"/storage/v1/b/my-bucket/o/my-object":                                          "/storage/v1/b/{}/o/{}",
+		"/no/version":                                                                  "/no/version",
+	}
+	for path, expected := range cases {
+		if got := AuditURLTemplate(path); got != expected {
+			t.Errorf("AuditURLTemplate(%q): expected %q, got %q", path, expected, got)
+		}
+	}
+}
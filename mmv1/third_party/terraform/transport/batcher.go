@@ -24,6 +24,32 @@ type RequestBatcher struct {
 	parentCtx context.Context
 	batches   map[string]*startedBatch
 	debugId   string
+	stats     BatcherStats
+}
+
+// BatcherStats counts the requests sent by a RequestBatcher, and is logged
+// after each batch is sent to help tune BatchingConfig.
+type BatcherStats struct {
+	// BatchesSent is the number of batches sent, including batches of a single request.
+	BatchesSent int
+	// RequestsSent is the number of requests combined into the batches sent.
+	RequestsSent int
+	// EarlyFlushes is the number of batches sent before SendAfter because they
+	// reached MaxBatchSize.
+	EarlyFlushes int
+	// SingleRetries is the number of requests retried on their own after their batch failed.
+	SingleRetries int
+	// CancelledRequests is the number of requests dropped from a batch because
+	// their context was done before the batch was sent.
+	CancelledRequests int
+}
+
+// AverageBatchSize returns the average number of requests per batch sent.
+func (s BatcherStats) AverageBatchSize() float64 {
+	if s.BatchesSent == 0 {
+		return 0
+	}
+	return float64(s.RequestsSent) / float64(s.BatchesSent)
 }
 
 // These types are meant to be the public interface to batchers. They define
@@ -92,6 +118,10 @@ type batchSubscriber struct {
 	// singleRequest is the original request this subscriber represents
 	singleRequest *BatchRequest
 
+	// ctx is the context of the waiting goroutine. If it is done before the
+	// batch is sent, the request is dropped from the batch.
+	ctx context.Context
+
 	// respCh is the channel created to communicate the result to a waiting goroutine.s
 	respCh chan batchResponse
 }
@@ -100,6 +130,10 @@ type batchSubscriber struct {
 type BatchingConfig struct {
 	SendAfter      time.Duration
 	EnableBatching bool
+	// MaxBatchSize is the maximum number of requests combined into a batch. A
+	// batch that reaches it is sent without waiting for SendAfter. Zero means
+	// batches are unbounded.
+	MaxBatchSize int
 }
 
 // Initializes a new batcher.
@@ -128,6 +162,7 @@ func (b *RequestBatcher) stop() {
 	defer b.Unlock()
 
 	log.Printf("[DEBUG] Stopping batcher %q", b.debugId)
+	b.logStats()
 	for batchKey, batch := range b.batches {
 		log.Printf("[DEBUG] Cancelling started batch for batchKey %q", batchKey)
 		batch.timer.Stop()
@@ -152,6 +187,21 @@ func (b *RequestBatcher) stop() {
 // "serviceusage:projects/$PROJECT/services:batchEnable", which mirrors the HTTP request:
 // POST https://serviceusage.googleapis.com/v1/projects/$PROJECT/services:batchEnable
 func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchRequest, timeout time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(b.parentCtx, timeout)
+	defer cancel()
+	return b.sendRequest(ctx, batchKey, request, timeout)
+}
+
+// SendRequestWithContext is like SendRequestWithTimeout, but waits until ctx is
+// done. If ctx is done before the request's batch is sent, the request is
+// dropped from the batch.
+func (b *RequestBatcher) SendRequestWithContext(ctx context.Context, batchKey string, request *BatchRequest) (interface{}, error) {
+	return b.sendRequest(ctx, batchKey, request, 0)
+}
+
+// sendRequest sends a request and waits for its response until ctx is done.
+// timeout is only used in error messages, and may be zero.
+func (b *RequestBatcher) sendRequest(ctx context.Context, batchKey string, request *BatchRequest, timeout time.Duration) (interface{}, error) {
 	if request == nil {
 		return nil, fmt.Errorf("error, cannot request batching for nil BatchRequest")
 	}
@@ -166,16 +216,17 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 		return request.SendF(request.ResourceName, request.Body)
 	}
 
-	respCh, err := b.registerBatchRequest(batchKey, request)
+	respCh, err := b.registerBatchRequest(ctx, batchKey, request)
 	if err != nil {
 		return nil, fmt.Errorf("error adding request to batch: %s", err)
 	}
 
-	ctx, cancel := context.WithTimeout(b.parentCtx, timeout)
-	defer cancel()
-
 	select {
-	case resp := <-respCh:
+	case resp, ok := <-respCh:
+		if !ok {
+			// The request was dropped from its batch because ctx is done.
+			break
+		}
 		if resp.err != nil {
 			return nil, errwrap.Wrapf(
 				fmt.Sprintf("Request `%s` returned error: {{err}}", request.DebugId),
@@ -184,6 +235,8 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 		return resp.body, nil
 	case <-ctx.Done():
 		break
+	case <-b.parentCtx.Done():
+		break
 	}
 	if b.parentCtx.Err() != nil {
 		switch b.parentCtx.Err() {
@@ -199,6 +252,9 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 	case context.Canceled:
 		return nil, fmt.Errorf("Request %s canceled", batchKey)
 	case context.DeadlineExceeded:
+		if timeout == 0 {
+			return nil, fmt.Errorf("Request %s timed out", batchKey)
+		}
 		return nil, fmt.Errorf("Request %s timed out after %v", batchKey, timeout)
 	default:
 		return nil, fmt.Errorf("Error making request %s: %v", batchKey, ctx.Err())
@@ -209,13 +265,18 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 // with the given batchKey. If a batch exists, this will combine the new
 // request into this existing batch. Else, this method manages starting a new
 // batch and adding it to the RequestBatcher's started batches.
-func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *BatchRequest) (<-chan batchResponse, error) {
+func (b *RequestBatcher) registerBatchRequest(ctx context.Context, batchKey string, newRequest *BatchRequest) (<-chan batchResponse, error) {
 	b.Lock()
 	defer b.Unlock()
 
 	// If batch already exists, combine this request into existing request.
 	if batch, ok := b.batches[batchKey]; ok {
-		return batch.addRequest(newRequest)
+		respCh, err := batch.addRequest(ctx, newRequest)
+		if err != nil {
+			return nil, err
+		}
+		b.flushIfFull(batchKey, batch)
+		return respCh, nil
 	}
 
 	// Batch doesn't exist for given batch key - create a new batch.
@@ -226,6 +287,7 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 	respCh := make(chan batchResponse, 1)
 	sub := batchSubscriber{
 		singleRequest: newRequest,
+		ctx:           ctx,
 		respCh:        respCh,
 	}
 
@@ -253,19 +315,55 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 			b.sendBatchWithSingleRetry(batchKey, batch)
 		}
 	})
+	b.flushIfFull(batchKey, b.batches[batchKey])
 
 	return respCh, nil
 }
 
+// flushIfFull sends batch without waiting for its timer if it has reached the
+// configured MaxBatchSize. The batcher must be locked.
+func (b *RequestBatcher) flushIfFull(batchKey string, batch *startedBatch) {
+	if b.MaxBatchSize <= 0 || len(batch.subscribers) < b.MaxBatchSize {
+		return
+	}
+	// If the timer already fired, it is waiting to pop and send the batch.
+	if !batch.timer.Stop() {
+		return
+	}
+	log.Printf("[DEBUG] Batch %q reached max batch size %d, sending early", batchKey, b.MaxBatchSize)
+	delete(b.batches, batchKey)
+	b.stats.EarlyFlushes++
+	go b.sendBatchWithSingleRetry(batchKey, batch)
+}
+
 func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *startedBatch) {
-	log.Printf("[DEBUG] Sending batch %q combining %d requests)", batchKey, len(batch.subscribers))
-	resp := batch.send()
+	subscribers := batch.dropCancelledSubscribers()
+	cancelled := len(batch.subscribers) - len(subscribers)
+	retries := 0
+	defer func() {
+		b.recordBatch(len(subscribers), retries, cancelled)
+	}()
+	if len(subscribers) == 0 {
+		log.Printf("[DEBUG] Not sending batch %q, all %d requests were cancelled", batchKey, cancelled)
+		return
+	}
+
+	var resp batchResponse
+	if cancelled > 0 {
+		log.Printf("[DEBUG] Dropped %d cancelled requests from batch %q", cancelled, batchKey)
+		resp = batch.recombine(subscribers)
+	}
+	if !resp.IsError() {
+		log.Printf("[DEBUG] Sending batch %q combining %d requests)", batchKey, len(subscribers))
+		resp = batch.send()
+	}
 
 	// If the batch failed and combines more than one request, retry each single request.
-	if resp.IsError() && len(batch.subscribers) > 1 {
+	if resp.IsError() && len(subscribers) > 1 {
 		log.Printf("[DEBUG] Batch failed with error: %v", resp.err)
 		log.Printf("[DEBUG] Sending each request in batch separately")
-		for _, sub := range batch.subscribers {
+		for _, sub := range subscribers {
+			retries++
 			log.Printf("[DEBUG] Retrying single request %q", sub.singleRequest.DebugId)
 			singleResp := sub.singleRequest.send()
 			log.Printf("[DEBUG] Retried single request %q returned response: %v", sub.singleRequest.DebugId, singleResp)
@@ -280,13 +378,41 @@ func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *starte
 		}
 	} else {
 		// Send result to all subscribers
-		for _, sub := range batch.subscribers {
+		for _, sub := range subscribers {
 			sub.respCh <- resp
 			close(sub.respCh)
 		}
 	}
 }
 
+// recordBatch adds a sent batch to the batcher's stats and logs them.
+func (b *RequestBatcher) recordBatch(size, retries, cancelled int) {
+	b.Lock()
+	defer b.Unlock()
+
+	if size > 0 {
+		b.stats.BatchesSent++
+		b.stats.RequestsSent += size
+	}
+	b.stats.SingleRetries += retries
+	b.stats.CancelledRequests += cancelled
+	b.logStats()
+}
+
+// logStats logs the batcher's stats. The batcher must be locked.
+func (b *RequestBatcher) logStats() {
+	log.Printf("[DEBUG] Batcher %q sent %d batches with an average of %.1f requests per batch (%d sent early at max batch size), "+
+		"retried %d requests singly and dropped %d cancelled requests",
+		b.debugId, b.stats.BatchesSent, b.stats.AverageBatchSize(), b.stats.EarlyFlushes, b.stats.SingleRetries, b.stats.CancelledRequests)
+}
+
+// Stats returns the counts of requests sent by the batcher so far.
+func (b *RequestBatcher) Stats() BatcherStats {
+	b.Lock()
+	defer b.Unlock()
+	return b.stats
+}
+
 // popBatch safely gets and removes a batch with given batchkey from the
 // RequestBatcher's started batches.
 func (b *RequestBatcher) popBatch(batchKey string) *startedBatch {
@@ -303,7 +429,7 @@ func (b *RequestBatcher) popBatch(batchKey string) *startedBatch {
 	return batch
 }
 
-func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchResponse, error) {
+func (batch *startedBatch) addRequest(ctx context.Context, newRequest *BatchRequest) (<-chan batchResponse, error) {
 	log.Printf("[DEBUG] Adding batch request %q to existing batch %q", newRequest.DebugId, batch.batchKey)
 	if batch.CombineF == nil {
 		return nil, fmt.Errorf("Provider Error: unable to add request %q to batch %q with no CombineF", newRequest.DebugId, batch.batchKey)
@@ -319,12 +445,45 @@ func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchRes
 	respCh := make(chan batchResponse, 1)
 	sub := batchSubscriber{
 		singleRequest: newRequest,
+		ctx:           ctx,
 		respCh:        respCh,
 	}
 	batch.subscribers = append(batch.subscribers, sub)
 	return respCh, nil
 }
 
+// dropCancelledSubscribers returns the subscribers of the batch whose context
+// isn't done, and closes the response channels of the others.
+func (batch *startedBatch) dropCancelledSubscribers() []batchSubscriber {
+	var subscribers []batchSubscriber
+	for _, sub := range batch.subscribers {
+		if sub.ctx != nil && sub.ctx.Err() != nil {
+			log.Printf("[DEBUG] Dropping cancelled request %q from batch %q: %v", sub.singleRequest.DebugId, batch.batchKey, sub.ctx.Err())
+			close(sub.respCh)
+			continue
+		}
+		subscribers = append(subscribers, sub)
+	}
+	return subscribers
+}
+
+// recombine replaces the body of the batch with the combined bodies of
+// subscribers, such as after cancelled requests were dropped from it.
+func (batch *startedBatch) recombine(subscribers []batchSubscriber) batchResponse {
+	body := subscribers[0].singleRequest.Body
+	for _, sub := range subscribers[1:] {
+		var err error
+		body, err = batch.CombineF(body, sub.singleRequest.Body)
+		if err != nil {
+			return batchResponse{
+				err: fmt.Errorf("Provider Error: Unable to recombine batch %q without cancelled requests: %v", batch.batchKey, err),
+			}
+		}
+	}
+	batch.Body = body
+	return batchResponse{}
+}
+
 func (req *BatchRequest) send() batchResponse {
 	if req.SendF == nil {
 		return batchResponse{
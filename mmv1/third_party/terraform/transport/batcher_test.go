@@ -292,3 +292,120 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 		}(i)
 	}
 }
+
+func TestRequestBatcher_maxBatchSize(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&BatchingConfig{
+			// Long enough that only full batches are sent before the test times out.
+			SendAfter:      time.Duration(1) * time.Minute,
+			EnableBatching: true,
+			MaxBatchSize:   3,
+		})
+
+	testCombine := func(currV interface{}, toAddV interface{}) (interface{}, error) {
+		return currV.(int) + toAddV.(int), nil
+	}
+
+	testSendBatch := func(name string, body interface{}) (interface{}, error) {
+		return body, nil
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(6)
+
+	for i := 0; i < 6; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			req := &BatchRequest{
+				DebugId:      fmt.Sprintf("Test Max Batch Size Request #%d", idx),
+				ResourceName: "testMaxBatchSize",
+				Body:         1,
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+			}
+
+			respV, err := testBatcher.SendRequestWithTimeout("testMaxBatchSize", req, time.Duration(5)*time.Second)
+			if err != nil {
+				t.Errorf("got unexpected error %s", err)
+				return
+			}
+			if respV != 3 {
+				t.Errorf("expected request to be sent in a batch of 3, got batch of %v", respV)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	stats := testBatcher.Stats()
+	if stats.BatchesSent != 2 || stats.EarlyFlushes != 2 || stats.AverageBatchSize() != 3 {
+		t.Errorf("expected 2 batches of 3 requests sent early, got stats %+v", stats)
+	}
+}
+
+func TestRequestBatcher_dropsCancelledRequests(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Duration(1) * time.Second,
+			EnableBatching: true,
+		})
+
+	testCombine := func(currV interface{}, toAddV interface{}) (interface{}, error) {
+		return currV.(int) + toAddV.(int), nil
+	}
+
+	var sentBody interface{}
+	testSendBatch := func(name string, body interface{}) (interface{}, error) {
+		sentBody = body
+		return body, nil
+	}
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+
+	for i := 0; i < 3; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			ctx := context.Background()
+			if idx == 0 {
+				ctx = cancelledCtx
+			}
+			req := &BatchRequest{
+				DebugId:      fmt.Sprintf("Test Cancelled Request #%d", idx),
+				ResourceName: "testCancelled",
+				Body:         1,
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+			}
+
+			_, err := testBatcher.SendRequestWithContext(ctx, "testCancelled", req)
+			if idx == 0 {
+				if err == nil || !strings.Contains(err.Error(), "canceled") {
+					t.Errorf("expected cancelled error, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("got unexpected error %s", err)
+			}
+		}(i)
+	}
+
+	// Cancel the first request while its batch is waiting to be sent.
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	if sentBody != 2 {
+		t.Errorf("expected batch to combine the 2 remaining requests, got %v", sentBody)
+	}
+	if stats := testBatcher.Stats(); stats.CancelledRequests != 1 || stats.RequestsSent != 2 {
+		t.Errorf("expected 1 cancelled and 2 sent requests, got stats %+v", stats)
+	}
+}
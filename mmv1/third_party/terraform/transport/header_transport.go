@@ -8,6 +8,9 @@ import (
 type headerTransportLayer struct {
 	http.Header
 	baseTransit http.RoundTripper
+	// quotaProjectRouting sets the X-Goog-User-Project header of requests that
+	// don't have one, before the default headers are applied.
+	quotaProjectRouting *QuotaProjectRouting
 }
 
 func NewTransportWithHeaders(baseTransit http.RoundTripper) headerTransportLayer {
@@ -20,7 +23,19 @@ func NewTransportWithHeaders(baseTransit http.RoundTripper) headerTransportLayer
 	return headerTransportLayer{Header: headers, baseTransit: baseTransit}
 }
 
+// WithQuotaProjectRouting returns a copy of the transport that routes the quota
+// project of each request with routing.
+func (h headerTransportLayer) WithQuotaProjectRouting(routing *QuotaProjectRouting) headerTransportLayer {
+	h.quotaProjectRouting = routing
+	return h
+}
+
 func (h headerTransportLayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Header["X-Goog-User-Project"]; !ok {
+		if project := h.quotaProjectRouting.QuotaProject(req.URL.Hostname(), req.URL.Path, QuotaProjectFromContext(req.Context())); project != "" {
+			req.Header.Set("X-Goog-User-Project", project)
+		}
+	}
 	for key, value := range h.Header {
 		// only set headers that are not previously defined
 		if _, ok := req.Header[key]; !ok {
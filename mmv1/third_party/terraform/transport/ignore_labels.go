@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IgnoreLabels are the labels that the provider leaves to other systems to
+// manage, such as billing tools and security scanners. They aren't recorded in
+// effective_labels and are preserved when the provider updates labels.
+type IgnoreLabels struct {
+	// Keys are the keys of ignored labels.
+	Keys []string
+	// KeyPrefixes are the prefixes of the keys of ignored labels.
+	KeyPrefixes []string
+}
+
+// IsEmpty returns whether no labels are ignored.
+func (i *IgnoreLabels) IsEmpty() bool {
+	return i == nil || (len(i.Keys) == 0 && len(i.KeyPrefixes) == 0)
+}
+
+// Ignores returns whether the label with key is ignored.
+func (i *IgnoreLabels) Ignores(key string) bool {
+	if i == nil {
+		return false
+	}
+	for _, k := range i.Keys {
+		if k == key {
+			return true
+		}
+	}
+	for _, p := range i.KeyPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandProviderIgnoreLabels converts the provider's ignore_labels block to
+// IgnoreLabels, returning nil if it isn't set.
+func ExpandProviderIgnoreLabels(v interface{}) (*IgnoreLabels, error) {
+	if v == nil {
+		return nil, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return nil, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	ignore := &IgnoreLabels{}
+	for key, field := range map[string]*[]string{
+		"keys":         &ignore.Keys,
+		"key_prefixes": &ignore.KeyPrefixes,
+	} {
+		values, _ := cfgV[key].([]interface{})
+		for _, value := range values {
+			s, _ := value.(string)
+			if s == "" {
+				return nil, fmt.Errorf("ignore_labels %s must not be empty", key)
+			}
+			*field = append(*field, s)
+		}
+	}
+
+	if ignore.IsEmpty() {
+		return nil, nil
+	}
+	return ignore, nil
+}
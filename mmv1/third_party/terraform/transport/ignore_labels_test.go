@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIgnoreLabels_Ignores(t *testing.T) {
+	ignore := &IgnoreLabels{
+		Keys:        []string{"cost-center"},
+		KeyPrefixes: []string{"scanner-"},
+	}
+	cases := map[string]bool{
+		"cost-center":       true,
+		"cost-center-2":     false,
+		"scanner-":          true,
+		"scanner-last-scan": true,
+		"env":               false,
+	}
+	for key, expected := range cases {
+		if got := ignore.Ignores(key); got != expected {
+			t.Errorf("Ignores(%q): expected %t, got %t", key, expected, got)
+		}
+	}
+
+	var unset *IgnoreLabels
+	if unset.Ignores("cost-center") || !unset.IsEmpty() {
+		t.Errorf("expected nil IgnoreLabels to ignore nothing")
+	}
+}
+
+func TestExpandProviderIgnoreLabels(t *testing.T) {
+	cases := map[string]struct {
+		Input       interface{}
+		Expected    *IgnoreLabels
+		ExpectError bool
+	}{
+		"unset": {
+			Input: []interface{}{},
+		},
+		"empty block": {
+			Input: []interface{}{nil},
+		},
+		"keys and prefixes": {
+			Input: []interface{}{map[string]interface{}{
+				"keys":         []interface{}{"cost-center"},
+				"key_prefixes": []interface{}{"scanner-", "billing-"},
+			}},
+			Expected: &IgnoreLabels{
+				Keys:        []string{"cost-center"},
+				KeyPrefixes: []string{"scanner-", "billing-"},
+			},
+		},
+		"empty prefix": {
+			Input: []interface{}{map[string]interface{}{
+				"key_prefixes": []interface{}{""},
+			}},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := ExpandProviderIgnoreLabels(tc.Input)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("expected %+v, got %+v", tc.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var quotaProjectPathRegex = regexp.MustCompile(`/projects/([^/:]+)`)
+
+// QuotaProjectRouting chooses the quota project of each request, sent in the
+// X-Goog-User-Project header when user_project_override is enabled, instead of
+// attributing every request to the provider's billing_project.
+type QuotaProjectRouting struct {
+	// ResourceProject attributes requests to the project of the resource they're
+	// for.
+	ResourceProject bool
+	// HostProjects attributes requests to an API host, e.g.
+	// "bigquery.googleapis.com", to a project. They take precedence over
+	// ResourceProject.
+	HostProjects map[string]string
+}
+
+// IsEmpty returns whether requests aren't routed, so are attributed to the
+// provider's billing_project.
+func (r *QuotaProjectRouting) IsEmpty() bool {
+	return r == nil || (!r.ResourceProject && len(r.HostProjects) == 0)
+}
+
+// QuotaProject returns the quota project of a request to host, or "" if the
+// request isn't routed. project is the quota project the request was sent
+// with, if any, such as the project of the resource it's for. If it's "", the
+// resource's project is taken from path, the path of the request's URL.
+func (r *QuotaProjectRouting) QuotaProject(host, path, project string) string {
+	if r.IsEmpty() {
+		return ""
+	}
+	if hostProject, ok := r.HostProjects[host]; ok {
+		return hostProject
+	}
+	if project != "" {
+		return project
+	}
+	if !r.ResourceProject {
+		return ""
+	}
+	if parts := quotaProjectPathRegex.FindStringSubmatch(path); parts != nil {
+		return parts[1]
+	}
+	return ""
+}
+
+type quotaProjectContextKey struct{}
+
+// ContextWithQuotaProject returns a copy of ctx that makes the header transport
+// route requests sent with it to project, unless their API host is routed to
+// another project.
+func ContextWithQuotaProject(ctx context.Context, project string) context.Context {
+	if project == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, quotaProjectContextKey{}, project)
+}
+
+// QuotaProjectFromContext returns the project set by ContextWithQuotaProject,
+// if any.
+func QuotaProjectFromContext(ctx context.Context) string {
+	project, _ := ctx.Value(quotaProjectContextKey{}).(string)
+	return project
+}
+
+// ExpandProviderQuotaProjectRouting converts the provider's
+// quota_project_routing block to QuotaProjectRouting, returning nil if it isn't
+// set.
+func ExpandProviderQuotaProjectRouting(v interface{}) (*QuotaProjectRouting, error) {
+	if v == nil {
+		return nil, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return nil, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	routing := &QuotaProjectRouting{}
+	if resourceProject, ok := cfgV["resource_project"]; ok {
+		routing.ResourceProject = resourceProject.(bool)
+	}
+	if hostProjects, ok := cfgV["host_projects"].(map[string]interface{}); ok && len(hostProjects) > 0 {
+		routing.HostProjects = make(map[string]string, len(hostProjects))
+		for host, project := range hostProjects {
+			if strings.Contains(host, "/") {
+				return nil, fmt.Errorf("quota_project_routing host_projects key %q must be a host name, e.g. \"bigquery.googleapis.com\"", host)
+			}
+			if project.(string) == "" {
+				return nil, fmt.Errorf("quota_project_routing host_projects project for %q must not be empty", host)
+			}
+			routing.HostProjects[host] = project.(string)
+		}
+	}
+
+	if routing.IsEmpty() {
+		return nil, nil
+	}
+	return routing, nil
+}
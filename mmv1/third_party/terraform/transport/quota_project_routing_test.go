@@ -0,0 +1,143 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestQuotaProjectRouting_QuotaProject(t *testing.T) {
+	cases := map[string]struct {
+		Routing  *QuotaProjectRouting
+		Host     string
+		Path     string
+		Project  string
+		Expected string
+	}{
+		"unset": {
+			Host:     "compute.googleapis.com",
+			Path:     "/compute/v1/projects/resource-project/zones/us-central1-a/instances/my-instance",
+			Project:  "billing-project",
+			Expected: "",
+		},
+		"host": {
+			Routing:  &QuotaProjectRouting{HostProjects: map[string]string{"bigquery.googleapis.com": "bq-project"}},
+			Host:     "bigquery.googleapis.com",
+			Path:     "/bigquery/v2/projects/resource-project/datasets/my-dataset",
+			Project:  "resource-project",
+			Expected: "bq-project",
+		},
+		"host not routed": {
+			Routing:  &QuotaProjectRouting{HostProjects: map[string]string{"bigquery.googleapis.com": "bq-project"}},
+			Host:     "compute.googleapis.com",
+			Path:     "/compute/v1/projects/resource-project/global/networks/my-network",
+			Project:  "billing-project",
+			Expected: "billing-project",
+		},
+		"resource project": {
+			Routing:  &QuotaProjectRouting{ResourceProject: true},
+			Host:     "compute.googleapis.com",
+			Path:     "/compute/v1/projects/resource-project/global/networks/my-network",
+			Project:  "resource-project",
+			Expected: "resource-project",
+		},
+		"resource project from path": {
+			Routing:  &QuotaProjectRouting{ResourceProject: true},
+			Host:     "cloudresourcemanager.googleapis.com",
+			Path:     "/v1/projects/resource-project:getIamPolicy",
+			Expected: "resource-project",
+		},
+		"no project in path": {
+			Routing:  &QuotaProjectRouting{ResourceProject: true},
+			Host:     "storage.googleapis.com",
+			Path:     "/storage/v1/b/my-bucket",
+			Expected: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := tc.Routing.QuotaProject(tc.Host, tc.Path, tc.Project); got != tc.Expected {
+				t.Errorf("expected quota project %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHeaderTransport_QuotaProjectRouting(t *testing.T) {
+	var got string
+	transport := NewTransportWithHeaders(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get("X-Goog-User-Project")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	transport.Set("X-Goog-User-Project", "billing-project")
+	transport = transport.WithQuotaProjectRouting(&QuotaProjectRouting{
+		ResourceProject: true,
+		HostProjects:    map[string]string{"bigquery.googleapis.com": "bq-project"},
+	})
+
+	cases := map[string]struct {
+		URL      string
+		Project  string
+		Expected string
+	}{
+		"host": {
+			URL:      "https://bigquery.googleapis.com/bigquery/v2/projects/resource-project/datasets",
+			Project:  "resource-project",
+			Expected: "bq-project",
+		},
+		"resource project": {
+			URL:      "https://compute.googleapis.com/compute/v1/projects/resource-project/global/networks",
+			Project:  "resource-project",
+			Expected: "resource-project",
+		},
+		"default": {
+			URL:      "https://storage.googleapis.com/storage/v1/b/my-bucket",
+			Expected: "billing-project",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(ContextWithQuotaProject(context.Background(), tc.Project), "GET", tc.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := transport.RoundTrip(req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.Expected {
+				t.Errorf("expected X-Goog-User-Project %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestExpandProviderQuotaProjectRouting(t *testing.T) {
+	routing, err := ExpandProviderQuotaProjectRouting([]interface{}{map[string]interface{}{
+		"resource_project": true,
+		"host_projects":    map[string]interface{}{"bigquery.googleapis.com": "bq-project"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !routing.ResourceProject || routing.HostProjects["bigquery.googleapis.com"] != "bq-project" {
+		t.Errorf("unexpected routing %+v", routing)
+	}
+
+	if routing, err := ExpandProviderQuotaProjectRouting([]interface{}{}); err != nil || routing != nil {
+		t.Errorf("expected no routing when unset, got %+v (%v)", routing, err)
+	}
+
+	if _, err := ExpandProviderQuotaProjectRouting([]interface{}{map[string]interface{}{
+		"host_projects": map[string]interface{}{"https://bigquery.googleapis.com/": "bq-project"},
+	}}); err == nil {
+		t.Errorf("expected error for a URL in host_projects")
+	}
+}
@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitTransport is a http.RoundTripper that limits the rate of requests
+// sent to API hosts with a token bucket per host, so that parallel operations
+// wait client-side instead of exhausting per-minute quotas and relying on
+// retries of 429 errors.
+//
+// Limits are keyed by host, e.g. "compute.googleapis.com", which limits every
+// request to the host, or by host and project, e.g.
+// "compute.googleapis.com/my-project", which limits requests to the host for
+// resources in that project. A request is limited by the most specific key
+// that matches it.
+type rateLimitTransport struct {
+	limits   map[string]float64
+	internal http.RoundTripper
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewTransportWithRateLimits constructs a transport that limits requests to
+// the rates in limits, in requests per second. Requests to hosts without a
+// limit are sent immediately.
+func NewTransportWithRateLimits(t http.RoundTripper, limits map[string]float64) *rateLimitTransport {
+	return &rateLimitTransport{
+		limits:   limits,
+		internal: t,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// RoundTrip implements the RoundTripper interface method. It waits for the
+// request's token bucket, if any, before sending the request.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if limiter, key := t.limiter(req); limiter != nil {
+		start := time.Now()
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("waiting for request rate limit %q: %w", key, err)
+		}
+		if wait := time.Since(start); wait >= time.Millisecond {
+			log.Printf("[DEBUG] Rate Limit Transport: Waited %s for request rate limit %q", wait.Round(time.Millisecond), key)
+		}
+	}
+	return t.internal.RoundTrip(req)
+}
+
+// limiter returns the token bucket limiting req and its key, or nil if req
+// isn't limited.
+func (t *rateLimitTransport) limiter(req *http.Request) (*rate.Limiter, string) {
+	if len(t.limits) == 0 || req.URL == nil {
+		return nil, ""
+	}
+	host := req.URL.Hostname()
+	key := host
+	if project := requestProject(req); project != "" {
+		if _, ok := t.limits[host+"/"+project]; ok {
+			key = host + "/" + project
+		}
+	}
+	limit, ok := t.limits[key]
+	if !ok {
+		return nil, ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limiter, ok := t.limiters[key]
+	if !ok {
+		// Allow bursts of up to a second's worth of requests.
+		limiter = rate.NewLimiter(rate.Limit(limit), int(math.Max(1, math.Ceil(limit))))
+		t.limiters[key] = limiter
+	}
+	return limiter, key
+}
+
+// requestProject returns the project a request is for, from its URL path
+// (e.g. /compute/v1/projects/my-project/zones/...) or, failing that, its
+// X-Goog-User-Project header.
+func requestProject(req *http.Request) string {
+	parts := strings.Split(req.URL.Path, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "projects" && parts[i+1] != "" {
+			return parts[i+1]
+		}
+	}
+	return req.Header.Get("X-Goog-User-Project")
+}
+
+// ExpandProviderRequestRateLimits returns the rate limits configured by the
+// provider's request_rate_limits field, in requests per second by key.
+func ExpandProviderRequestRateLimits(v interface{}) (map[string]float64, error) {
+	limits := make(map[string]float64)
+	if v == nil {
+		return limits, nil
+	}
+	for key, limitV := range v.(map[string]interface{}) {
+		limit, ok := limitV.(float64)
+		if !ok || limit <= 0 || math.IsInf(limit, 0) || math.IsNaN(limit) {
+			return nil, fmt.Errorf("request_rate_limits value for %q must be a positive number, got %v", key, limitV)
+		}
+		if key == "" || strings.Count(key, "/") > 1 || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+			return nil, fmt.Errorf("request_rate_limits key %q must be an API host, optionally followed by /PROJECT", key)
+		}
+		limits[key] = limit
+	}
+	return limits, nil
+}
@@ -0,0 +1,147 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport_LimitsRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ts.Client()
+	client.Transport = NewTransportWithRateLimits(http.DefaultTransport, map[string]float64{
+		u.Hostname(): 10,
+	})
+
+	// The first 10 requests use the initial burst, and the next 5 are sent
+	// at 10 requests per second.
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, but 15 requests took %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_Limiter(t *testing.T) {
+	transport := NewTransportWithRateLimits(http.DefaultTransport, map[string]float64{
+		"compute.googleapis.com":            20,
+		"compute.googleapis.com/my-project": 5,
+	})
+
+	cases := map[string]struct {
+		URL         string
+		Header      http.Header
+		ExpectedKey string
+	}{
+		"host": {
+			URL:         "https://compute.googleapis.com/compute/v1/projects/other-project/zones/us-central1-a/instances",
+			ExpectedKey: "compute.googleapis.com",
+		},
+		"host and project from path": {
+			URL:         "https://compute.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances",
+			ExpectedKey: "compute.googleapis.com/my-project",
+		},
+		"host and project from header": {
+			URL:         "https://compute.googleapis.com/compute/v1/operations",
+			Header:      http.Header{"X-Goog-User-Project": []string{"my-project"}},
+			ExpectedKey: "compute.googleapis.com/my-project",
+		},
+		"host without limit": {
+			URL: "https://storage.googleapis.com/storage/v1/b",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tc.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.Header != nil {
+				req.Header = tc.Header
+			}
+
+			limiter, key := transport.limiter(req)
+			if key != tc.ExpectedKey {
+				t.Errorf("expected key %q, got %q", tc.ExpectedKey, key)
+			}
+			if (limiter == nil) != (tc.ExpectedKey == "") {
+				t.Errorf("expected limiter for key %q, got %v", tc.ExpectedKey, limiter)
+			}
+		})
+	}
+}
+
+func TestExpandProviderRequestRateLimits(t *testing.T) {
+	cases := map[string]struct {
+		Value       interface{}
+		Expected    map[string]float64
+		ExpectError bool
+	}{
+		"unset": {
+			Value:    nil,
+			Expected: map[string]float64{},
+		},
+		"hosts and projects": {
+			Value: map[string]interface{}{
+				"compute.googleapis.com":            20.0,
+				"compute.googleapis.com/my-project": 0.5,
+			},
+			Expected: map[string]float64{
+				"compute.googleapis.com":            20,
+				"compute.googleapis.com/my-project": 0.5,
+			},
+		},
+		"zero limit": {
+			Value: map[string]interface{}{
+				"compute.googleapis.com": 0.0,
+			},
+			ExpectError: true,
+		},
+		"invalid key": {
+			Value: map[string]interface{}{
+				"compute.googleapis.com/projects/my-project": 1.0,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := ExpandProviderRequestRateLimits(tc.Value)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected error, got limits %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tc.Expected) {
+				t.Fatalf("expected limits %v, got %v", tc.Expected, got)
+			}
+			for k, v := range tc.Expected {
+				if got[k] != v {
+					t.Errorf("expected limit %v for %q, got %v", v, k, got[k])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// readOnlyAllowedMethods are the custom methods that are sent with POST but
+// don't modify resources, so are allowed in read-only mode.
+var readOnlyAllowedMethods = []string{
+	":getIamPolicy",
+	":testIamPermissions",
+	":batchGet",
+	":getEffectiveIamPolicies",
+	":generateAccessToken",
+	":generateIdToken",
+	":signJwt",
+	":decrypt",
+	":lookup",
+	":search",
+}
+
+// ReadOnlyError is returned for requests that the provider refused to send
+// because they may modify resources and the provider is in read-only mode.
+type ReadOnlyError struct {
+	// Resource is the type of the Terraform resource the request was for, if
+	// known.
+	Resource string
+	Method   string
+	URL      string
+}
+
+func (e *ReadOnlyError) Error() string {
+	if e.Resource == "" {
+		return fmt.Sprintf("the provider is configured with read_only = true, refusing to send %s %s, which may modify resources", e.Method, e.URL)
+	}
+	return fmt.Sprintf("the provider is configured with read_only = true, refusing to send %s %s, which may modify %s", e.Method, e.URL, e.Resource)
+}
+
+// readOnlyTransport is a http.RoundTripper that refuses to send requests that
+// may modify resources, so that a provider configured with broad credentials
+// can refresh, plan and import without changing infrastructure.
+type readOnlyTransport struct {
+	internal http.RoundTripper
+}
+
+// NewTransportWithReadOnly constructs a transport that only sends requests
+// that read resources.
+func NewTransportWithReadOnly(t http.RoundTripper) *readOnlyTransport {
+	return &readOnlyTransport{
+		internal: t,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface method. It returns a
+// ReadOnlyError instead of sending requests that may modify resources.
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !IsReadOnlyRequest(req) {
+		u := *req.URL
+		u.RawQuery = ""
+		err := &ReadOnlyError{
+			Resource: AuditResourceFromContext(req.Context()),
+			Method:   req.Method,
+			URL:      u.String(),
+		}
+		log.Printf("[WARN] Read Only Transport: %s", err)
+		return nil, err
+	}
+	return t.internal.RoundTrip(req)
+}
+
+// IsReadOnlyRequest returns whether req only reads resources: GET, HEAD and
+// OPTIONS requests, and POST requests for custom methods that don't modify
+// resources, such as getIamPolicy.
+func IsReadOnlyRequest(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		for _, m := range readOnlyAllowedMethods {
+			if strings.HasSuffix(req.URL.Path, m) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	sent := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = NewTransportWithReadOnly(http.DefaultTransport)
+
+	cases := map[string]struct {
+		Method      string
+		Path        string
+		ExpectError bool
+	}{
+		"get": {
+			Method: "GET",
+			Path:   "/v1/projects/my-project/widgets/my-widget",
+		},
+		"list": {
+			Method: "GET",
+			Path:   "/v1/projects/my-project/widgets",
+		},
+		"get iam policy": {
+			Method: "POST",
+			Path:   "/v1/projects/my-project/widgets/my-widget:getIamPolicy",
+		},
+		"create": {
+			Method:      "POST",
+			Path:        "/v1/projects/my-project/widgets",
+			ExpectError: true,
+		},
+		"update": {
+			Method:      "PATCH",
+			Path:        "/v1/projects/my-project/widgets/my-widget",
+			ExpectError: true,
+		},
+		"delete": {
+			Method:      "DELETE",
+			Path:        "/v1/projects/my-project/widgets/my-widget",
+			ExpectError: true,
+		},
+		"generate access token": {
+			Method: "POST",
+			Path:   "/v1/projects/-/serviceAccounts/sa@my-project.iam.gserviceaccount.com:generateAccessToken",
+		},
+		"decrypt": {
+			Method: "POST",
+			Path:   "/v1/projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key:decrypt",
+		},
+		"set iam policy": {
+			Method:      "POST",
+			Path:        "/v1/projects/my-project/widgets/my-widget:setIamPolicy",
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			sent = 0
+			req, err := http.NewRequest(tc.Method, ts.URL+tc.Path+"?alt=json", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req = req.WithContext(ContextWithAuditResource(req.Context(), "google_widget"))
+			resp, err := client.Do(req)
+			if !tc.ExpectError {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				resp.Body.Close()
+				if sent != 1 {
+					t.Errorf("expected request to be sent")
+				}
+				return
+			}
+
+			var readOnlyErr *ReadOnlyError
+			if !errors.As(err, &readOnlyErr) {
+				t.Fatalf("expected ReadOnlyError, got %v", err)
+			}
+			if !strings.HasSuffix(readOnlyErr.URL, tc.Path) {
+				t.Errorf("expected error to name %q, got %q", tc.Path, readOnlyErr.URL)
+			}
+			if !strings.Contains(readOnlyErr.Error(), "google_widget") {
+				t.Errorf("expected error to name the resource, got %q", readOnlyErr.Error())
+			}
+			if sent != 0 {
+				t.Errorf("expected request not to be sent")
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how long the retry transport waits between attempts at
+// a request that failed with a retryable error, and how many attempts it makes.
+type RetryPolicy struct {
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts.
+	MaxBackoff time.Duration
+	// Multiplier is applied to the wait after each retry.
+	Multiplier float64
+	// Jitter waits for a random duration between zero and the computed wait
+	// ("full jitter"), so that parallel requests that failed together don't
+	// retry together.
+	Jitter bool
+	// MaxAttempts is the maximum number of attempts, including the first. Zero
+	// means that attempts are bounded only by the request's context.
+	MaxAttempts int
+	// HonorRetryAfter waits at least as long as the Retry-After header of a
+	// failed response asks for, even if that is longer than MaxBackoff.
+	HonorRetryAfter bool
+}
+
+// DefaultRetryPolicy is used by retry transports that aren't configured with a
+// policy of their own.
+var DefaultRetryPolicy = RetryPolicy{
+	InitialBackoff:  500 * time.Millisecond,
+	MaxBackoff:      30 * time.Second,
+	Multiplier:      1.6,
+	Jitter:          true,
+	HonorRetryAfter: true,
+}
+
+// Validate returns an error if the policy can't be used.
+func (p RetryPolicy) Validate() error {
+	if p.InitialBackoff <= 0 {
+		return fmt.Errorf("initial backoff must be positive, got %s", p.InitialBackoff)
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		return fmt.Errorf("max backoff %s must not be less than initial backoff %s", p.MaxBackoff, p.InitialBackoff)
+	}
+	if p.Multiplier < 1 {
+		return fmt.Errorf("multiplier must be at least 1, got %v", p.Multiplier)
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts must not be negative, got %d", p.MaxAttempts)
+	}
+	return nil
+}
+
+// Backoff returns the wait before the next attempt, after attempts attempts
+// have been made. resp is the response of the last attempt, if any.
+func (p RetryPolicy) Backoff(attempts int, resp *http.Response) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempts-1))
+	wait := time.Duration(math.Min(backoff, float64(p.MaxBackoff)))
+	if p.Jitter && wait > 0 {
+		wait = time.Duration(rand.Int63n(int64(wait) + 1))
+	}
+	if p.HonorRetryAfter {
+		if retryAfter, ok := parseRetryAfter(resp, time.Now()); ok && retryAfter > wait {
+			wait = retryAfter
+		}
+	}
+	return wait
+}
+
+// ExhaustedAttempts reports whether no more attempts may be made after
+// attempts attempts.
+func (p RetryPolicy) ExhaustedAttempts(attempts int) bool {
+	return p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
+// parseRetryAfter returns the wait requested by the Retry-After header of
+// resp, given in either delay-seconds or HTTP-date form.
+func parseRetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+type retryPolicyContextKey struct{}
+
+// ContextWithRetryPolicy returns a copy of ctx that makes retry transports use
+// policy for requests sent with it, overriding the transport's own policy.
+func ContextWithRetryPolicy(ctx context.Context, policy *RetryPolicy) context.Context {
+	if policy == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, retryPolicyContextKey{}, policy)
+}
+
+// RetryPolicyFromContext returns the policy set by ContextWithRetryPolicy, if any.
+func RetryPolicyFromContext(ctx context.Context) *RetryPolicy {
+	policy, _ := ctx.Value(retryPolicyContextKey{}).(*RetryPolicy)
+	return policy
+}
+
+type retriesExhaustedContextKey struct{}
+
+// ContextWithRetriesExhausted returns a copy of ctx in which retry transports
+// set *exhausted when they stop retrying a request sent with it because their
+// policy's max attempts were reached, so that callers don't retry it again.
+func ContextWithRetriesExhausted(ctx context.Context, exhausted *bool) context.Context {
+	return context.WithValue(ctx, retriesExhaustedContextKey{}, exhausted)
+}
+
+func recordRetriesExhausted(ctx context.Context) {
+	if exhausted, ok := ctx.Value(retriesExhaustedContextKey{}).(*bool); ok {
+		*exhausted = true
+	}
+}
+
+// ExpandProviderRetryPolicy returns the retry policy configured by the
+// provider's retry_policy block. Unset fields take their value from
+// DefaultRetryPolicy, except that an unset max_backoff is raised to the
+// initial backoff if that is longer.
+func ExpandProviderRetryPolicy(v interface{}) (*RetryPolicy, error) {
+	policy := DefaultRetryPolicy
+
+	if v == nil {
+		return &policy, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return &policy, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	maxBackoffSet := false
+	for key, field := range map[string]*time.Duration{
+		"initial_backoff": &policy.InitialBackoff,
+		"max_backoff":     &policy.MaxBackoff,
+	} {
+		if durationV, ok := cfgV[key]; ok && durationV != "" {
+			d, err := time.ParseDuration(durationV.(string))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse duration from '%s' value %q", key, durationV)
+			}
+			*field = d
+			maxBackoffSet = maxBackoffSet || key == "max_backoff"
+		}
+	}
+	if !maxBackoffSet && policy.MaxBackoff < policy.InitialBackoff {
+		policy.MaxBackoff = policy.InitialBackoff
+	}
+	if multiplier, ok := cfgV["multiplier"]; ok && multiplier.(float64) != 0 {
+		policy.Multiplier = multiplier.(float64)
+	}
+	if jitter, ok := cfgV["jitter"]; ok {
+		policy.Jitter = jitter.(bool)
+	}
+	if maxAttempts, ok := cfgV["max_attempts"]; ok {
+		policy.MaxAttempts = maxAttempts.(int)
+	}
+	if honorRetryAfter, ok := cfgV["honor_retry_after"]; ok {
+		policy.HonorRetryAfter = honorRetryAfter.(bool)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retry_policy: %w", err)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,209 @@
+package transport
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := policy.Backoff(i+1, nil); got != want {
+			t.Errorf("attempt %d: expected backoff %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+		Multiplier:     1,
+		Jitter:         true,
+	}
+	for i := 0; i < 100; i++ {
+		if got := policy.Backoff(1, nil); got < 0 || got > time.Second {
+			t.Fatalf("expected backoff between 0 and 1s, got %s", got)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffRetryAfter(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+		Multiplier:     1,
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"10"}}}
+
+	if got := policy.Backoff(1, resp); got != time.Second {
+		t.Errorf("expected Retry-After to be ignored, got backoff %s", got)
+	}
+	policy.HonorRetryAfter = true
+	if got := policy.Backoff(1, resp); got != 10*time.Second {
+		t.Errorf("expected backoff of 10s from Retry-After, got %s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		Value    string
+		Expected time.Duration
+		OK       bool
+	}{
+		"unset": {},
+		"seconds": {
+			Value:    "120",
+			Expected: 2 * time.Minute,
+			OK:       true,
+		},
+		"negative seconds": {
+			Value: "-1",
+		},
+		"http date": {
+			Value:    now.Add(30 * time.Second).Format(http.TimeFormat),
+			Expected: 30 * time.Second,
+			OK:       true,
+		},
+		"http date in the past": {
+			Value:    now.Add(-30 * time.Second).Format(http.TimeFormat),
+			Expected: 0,
+			OK:       true,
+		},
+		"invalid": {
+			Value: "soon",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.Value != "" {
+				resp.Header.Set("Retry-After", tc.Value)
+			}
+			got, ok := parseRetryAfter(resp, now)
+			if ok != tc.OK || got != tc.Expected {
+				t.Errorf("expected (%s, %t), got (%s, %t)", tc.Expected, tc.OK, got, ok)
+			}
+		})
+	}
+}
+
+func TestExpandProviderRetryPolicy(t *testing.T) {
+	cases := map[string]struct {
+		Value       interface{}
+		Expected    RetryPolicy
+		ExpectError bool
+	}{
+		"unset": {
+			Value:    nil,
+			Expected: DefaultRetryPolicy,
+		},
+		"empty block": {
+			Value:    []interface{}{nil},
+			Expected: DefaultRetryPolicy,
+		},
+		"all fields": {
+			Value: []interface{}{
+				map[string]interface{}{
+					"initial_backoff":   "1s",
+					"max_backoff":       "1m",
+					"multiplier":        3.0,
+					"jitter":            false,
+					"max_attempts":      5,
+					"honor_retry_after": false,
+				},
+			},
+			Expected: RetryPolicy{
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Minute,
+				Multiplier:     3,
+				MaxAttempts:    5,
+			},
+		},
+		"unset fields use defaults": {
+			Value: []interface{}{
+				map[string]interface{}{
+					"initial_backoff":   "",
+					"max_backoff":       "",
+					"multiplier":        0.0,
+					"jitter":            true,
+					"max_attempts":      2,
+					"honor_retry_after": true,
+				},
+			},
+			Expected: RetryPolicy{
+				InitialBackoff:  DefaultRetryPolicy.InitialBackoff,
+				MaxBackoff:      DefaultRetryPolicy.MaxBackoff,
+				Multiplier:      DefaultRetryPolicy.Multiplier,
+				Jitter:          true,
+				MaxAttempts:     2,
+				HonorRetryAfter: true,
+			},
+		},
+		"initial backoff above default max backoff": {
+			Value: []interface{}{
+				map[string]interface{}{
+					"initial_backoff": "1m",
+				},
+			},
+			Expected: RetryPolicy{
+				InitialBackoff:  time.Minute,
+				MaxBackoff:      time.Minute,
+				Multiplier:      DefaultRetryPolicy.Multiplier,
+				Jitter:          DefaultRetryPolicy.Jitter,
+				HonorRetryAfter: DefaultRetryPolicy.HonorRetryAfter,
+			},
+		},
+		"invalid duration": {
+			Value: []interface{}{
+				map[string]interface{}{
+					"initial_backoff": "10",
+				},
+			},
+			ExpectError: true,
+		},
+		"max backoff less than initial backoff": {
+			Value: []interface{}{
+				map[string]interface{}{
+					"initial_backoff": "1m",
+					"max_backoff":     "1s",
+				},
+			},
+			ExpectError: true,
+		},
+		"multiplier less than one": {
+			Value: []interface{}{
+				map[string]interface{}{
+					"multiplier": 0.5,
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := ExpandProviderRetryPolicy(tc.Value)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected error, got policy %#v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tc.Expected {
+				t.Errorf("expected policy %#v, got %#v", tc.Expected, *got)
+			}
+		})
+	}
+}
@@ -71,9 +71,29 @@ func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFu
 	return &copyT
 }
 
+// Returns a shallow copy of the retry transport that waits between attempts
+// according to policy. A nil policy uses DefaultRetryPolicy.
+func (t *retryTransport) WithRetryPolicy(policy *RetryPolicy) *retryTransport {
+	copyT := *t
+	copyT.policy = policy
+	return &copyT
+}
+
 type retryTransport struct {
 	retryPredicates []RetryErrorPredicateFunc
-	internal        http.RoundTripper
+	// policy is overridden for a request by a policy set with ContextWithRetryPolicy.
+	policy   *RetryPolicy
+	internal http.RoundTripper
+}
+
+func (t *retryTransport) retryPolicy(ctx context.Context) RetryPolicy {
+	if policy := RetryPolicyFromContext(ctx); policy != nil {
+		return *policy
+	}
+	if t.policy != nil {
+		return *t.policy
+	}
+	return DefaultRetryPolicy
 }
 
 // RoundTrip implements the RoundTripper interface method.
@@ -93,9 +113,8 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 		}()
 	}
 
+	policy := t.retryPolicy(ctx)
 	attempts := 0
-	backoff := time.Millisecond * 500
-	nextBackoff := time.Millisecond * 500
 
 	// VCR depends on the original request body being consumed, so
 	// consume here. Since this won't affect the request itself,
@@ -116,6 +135,7 @@ Retry:
 			log.Printf("[WARN] Retry Transport: Unable to copy request body: %v.", copyErr)
 			log.Printf("[WARN] Retry Transport: Running request as non-retryable")
 			resp, respErr = t.internal.RoundTrip(req)
+			recordAuditAttempt(req.Context())
 			break Retry
 		}
 
@@ -123,6 +143,7 @@ Retry:
 		// Do the wrapped Roundtrip. This is one request in the retry loop.
 		resp, respErr = t.internal.RoundTrip(newRequest)
 		attempts++
+		recordAuditAttempt(req.Context())
 
 		retryErr := t.checkForRetryableError(resp, respErr)
 		if retryErr == nil {
@@ -133,7 +154,13 @@ Retry:
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, last request failed with non-retryable error: %s", retryErr.Err)
 			break Retry
 		}
+		if policy.ExhaustedAttempts(attempts) {
+			log.Printf("[DEBUG] Retry Transport: Stopping retries, reached max attempts (%d)", policy.MaxAttempts)
+			recordRetriesExhausted(req.Context())
+			break Retry
+		}
 
+		backoff := policy.Backoff(attempts, resp)
 		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
 		select {
 		case <-ctx.Done():
@@ -141,11 +168,6 @@ Retry:
 			break Retry
 		case <-time.After(backoff):
 			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
-
-			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
-			lastBackoff := backoff
-			backoff = backoff + nextBackoff
-			nextBackoff = lastBackoff
 			continue
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +20,16 @@ const testRetryTransportCodeRetry = 500
 const testRetryTransportCodeSuccess = 200
 const testRetryTransportCodeFailure = 400
 
+// testRetryTransportPolicy waits 500ms, 1s, 2s... between attempts, so tests that depend on
+// when a retry happens aren't at the mercy of DefaultRetryPolicy's jitter.
+var testRetryTransportPolicy = RetryPolicy{
+	InitialBackoff:  500 * time.Millisecond,
+	MaxBackoff:      30 * time.Second,
+	Multiplier:      2,
+	Jitter:          false,
+	HonorRetryAfter: true,
+}
+
 func setUpRetryTransportServerClient(hf http.Handler) (*httptest.Server, *http.Client) {
 	ts := httptest.NewServer(hf)
 
@@ -26,6 +37,7 @@ func setUpRetryTransportServerClient(hf http.Handler) (*httptest.Server, *http.C
 	client.Transport = &retryTransport{
 		internal:        http.DefaultTransport,
 		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		policy:          &testRetryTransportPolicy,
 	}
 	return ts, client
 }
@@ -104,7 +116,96 @@ func TestRetryTransport_ContextTimeout(t *testing.T) {
 	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
 }
 
+// Check that retries stop once the policy's max attempts are reached
+func TestRetryTransport_MaxAttempts(t *testing.T) {
+	var attempts int32
+	ts, client := setUpRetryTransportServerClient(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(testRetryTransportCodeRetry)
+		}))
+	defer ts.Close()
+	client.Transport = client.Transport.(*retryTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		MaxAttempts:    3,
+	})
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+// Check that a policy set on the request context overrides the transport's policy
+func TestRetryTransport_ContextRetryPolicy(t *testing.T) {
+	var attempts int32
+	ts, client := setUpRetryTransportServerClient(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(testRetryTransportCodeRetry)
+		}))
+	defer ts.Close()
+	client.Transport = client.Transport.(*retryTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		MaxAttempts:    5,
+	})
+
+	ctx := ContextWithRetryPolicy(context.Background(), &RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		MaxAttempts:    1,
+	})
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct err: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
 // Check for no errors if the request succeeds after a certain amount of time
+// Check that SendRequest doesn't retry a request again once the retry transport reaches its
+// policy's max attempts
+func TestSendRequest_RetriesExhausted(t *testing.T) {
+	var attempts int32
+	ts, client := setUpRetryTransportServerClient(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(testRetryTransportCodeRetry)
+		}))
+	defer ts.Close()
+	client.Transport = client.Transport.(*retryTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		MaxAttempts:    2,
+	})
+
+	_, err := SendRequest(SendRequestOptions{
+		Config:    &Config{Client: client},
+		Method:    "GET",
+		RawURL:    ts.URL,
+		UserAgent: "test",
+		Timeout:   10 * time.Second,
+	})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
 func TestRetryTransport_SuccessWithBody(t *testing.T) {
 	ts, client := setUpRetryTransportServerClient(
 		// Request succeeds after a certain amount of time and returns the body
@@ -0,0 +1,244 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/hashicorp/terraform-provider-google"
+
+// Span attributes set by the provider, in addition to the OpenTelemetry
+// semantic convention attributes for HTTP requests.
+const (
+	TraceResourceTypeKey = attribute.Key("terraform.resource.type")
+	TraceProductKey      = attribute.Key("gcp.product")
+)
+
+var (
+	tracingOnce     sync.Once
+	tracingProvider *sdktrace.TracerProvider
+)
+
+// TracingEnabled returns whether the standard OTEL_* environment variables
+// configure an OTLP endpoint to export traces to.
+func TracingEnabled() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+	if strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// ConfigureTracing registers a tracer provider that exports spans over OTLP,
+// configured by the standard OTEL_* environment variables, if TracingEnabled.
+// It's safe to call more than once, e.g. by each of the muxed providers.
+func ConfigureTracing(ctx context.Context) {
+	tracingOnce.Do(func() {
+		if !TracingEnabled() {
+			return
+		}
+
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			log.Printf("[WARN] Unable to create OTLP trace exporter, traces won't be exported: %s", err)
+			return
+		}
+		res, err := resource.New(ctx,
+			resource.WithAttributes(attribute.String("service.name", "terraform-provider-google")),
+			resource.WithFromEnv(),
+			resource.WithTelemetrySDK(),
+		)
+		if err != nil {
+			log.Printf("[WARN] Unable to detect OpenTelemetry resource: %s", err)
+		}
+		tracingProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+		otel.SetTracerProvider(tracingProvider)
+		log.Printf("[INFO] Exporting OpenTelemetry traces over OTLP")
+	})
+}
+
+// flushTraces exports the spans ended so far. Terraform stops the provider's
+// process without warning once it's done with it, so spans are flushed after
+// each call to the provider instead of when the process exits.
+func flushTraces(ctx context.Context) {
+	if tracingProvider == nil {
+		return
+	}
+	if err := tracingProvider.ForceFlush(ctx); err != nil {
+		log.Printf("[WARN] Unable to export OpenTelemetry traces: %s", err)
+	}
+}
+
+// Tracer returns the tracer for the provider's spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// tracingTransport is a http.RoundTripper that records a span for every
+// request it sends. It's wrapped by the retry transport, so each attempt at a
+// request has its own span.
+type tracingTransport struct {
+	internal http.RoundTripper
+}
+
+// NewTransportWithTracing constructs a transport that records a span for
+// every request.
+func NewTransportWithTracing(t http.RoundTripper) *tracingTransport {
+	return &tracingTransport{
+		internal: t,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface method. It sends the request
+// in a span that's a child of the span in the request's context, if any.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", host),
+		attribute.String("url.template", AuditURLTemplate(req.URL.Path)),
+		TraceProductKey.String(strings.Split(host, ".")[0]),
+	}
+	if resourceType := AuditResourceFromContext(req.Context()); resourceType != "" {
+		attrs = append(attrs, TraceResourceTypeKey.String(resourceType))
+	}
+	ctx, span := Tracer().Start(req.Context(), fmt.Sprintf("%s %s", req.Method, host),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	resp, err := t.internal.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, err
+}
+
+// ContextWithTraceSpan returns a copy of ctx with the span in traceCtx, if any,
+// so that requests sent with it are recorded as children of that span without
+// taking on traceCtx's deadline or cancellation.
+func ContextWithTraceSpan(ctx context.Context, traceCtx context.Context) context.Context {
+	if traceCtx == nil {
+		return ctx
+	}
+	span := trace.SpanFromContext(traceCtx)
+	if !span.SpanContext().IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, span)
+}
+
+// TraceResource wraps the CRUD functions of r, the resource named name, so
+// that each call is recorded in a span. Requests sent with the Config passed
+// to them are recorded as children of the span.
+func TraceResource(name string, r *schema.Resource) {
+	wrap := func(action string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			meta, _, end := startResourceSpan(nil, name, action, d, meta)
+			err := f(d, meta)
+			end(err)
+			return err
+		}
+	}
+	wrapContext := func(action string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			meta, ctx, end := startResourceSpan(ctx, name, action, d, meta)
+			diags := f(ctx, d, meta)
+			var err error
+			if diags.HasError() {
+				err = fmt.Errorf("%s", diags[0].Summary)
+			}
+			end(err)
+			return diags
+		}
+	}
+
+	r.Create = wrap("Create", r.Create)
+	r.Read = wrap("Read", r.Read)
+	r.Update = wrap("Update", r.Update)
+	r.Delete = wrap("Delete", r.Delete)
+	r.CreateContext = wrapContext("Create", r.CreateContext)
+	r.ReadContext = wrapContext("Read", r.ReadContext)
+	r.UpdateContext = wrapContext("Update", r.UpdateContext)
+	r.DeleteContext = wrapContext("Delete", r.DeleteContext)
+	r.CreateWithoutTimeout = wrapContext("Create", r.CreateWithoutTimeout)
+	r.ReadWithoutTimeout = wrapContext("Read", r.ReadWithoutTimeout)
+	r.UpdateWithoutTimeout = wrapContext("Update", r.UpdateWithoutTimeout)
+	r.DeleteWithoutTimeout = wrapContext("Delete", r.DeleteWithoutTimeout)
+}
+
+// startResourceSpan starts the span of a CRUD call and returns a copy of meta
+// whose Context carries the span, ctx with the span, and a function that ends
+// the span with the call's error. ctx is the context the SDK passed to the
+// call, or nil for CRUD functions that don't take one, in which case the span
+// is started from the provider's context.
+func startResourceSpan(ctx context.Context, name, action string, d *schema.ResourceData, meta interface{}) (interface{}, context.Context, func(error)) {
+	config, ok := meta.(*Config)
+	if !ok {
+		return meta, ctx, func(error) {}
+	}
+	if ctx == nil {
+		ctx = config.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+	}
+
+	ctx, span := Tracer().Start(ctx, fmt.Sprintf("%s %s", action, name),
+		trace.WithAttributes(
+			TraceResourceTypeKey.String(name),
+			TraceProductKey.String(strings.Split(strings.TrimPrefix(name, "google_"), "_")[0]),
+			attribute.String("terraform.resource.id", d.Id()),
+		),
+	)
+
+	// Only the span is attached to the provider's context, which keeps its own
+	// cancellation rather than taking on the deadline of the call.
+	tracedConfig := *config
+	if config.Context != nil {
+		tracedConfig.Context = trace.ContextWithSpan(config.Context, span)
+	} else {
+		tracedConfig.Context = ctx
+	}
+	return &tracedConfig, ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		flushTraces(context.WithoutCancel(ctx))
+	}
+}
@@ -0,0 +1,136 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = &retryTransport{
+		internal:        NewTransportWithTracing(http.DefaultTransport),
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		policy: &RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			Multiplier:     1,
+		},
+	}
+
+	ctx, parent := Tracer().Start(context.Background(), "Create google_widget")
+	req, err := http.NewRequest("GET", ts.URL+"/v1/projects/my-project/widgets/my-widget", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = req.WithContext(ContextWithAuditResource(ContextWithTraceSpan(context.Background(), ctx), "google_widget"))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	parent.End()
+
+	var requestSpans []sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() != "Create google_widget" {
+			requestSpans = append(requestSpans, s)
+		}
+	}
+	if len(requestSpans) != 2 {
+		t.Fatalf("expected a span for each of 2 attempts, got %d", len(requestSpans))
+	}
+
+	expectedStatuses := []int64{testRetryTransportCodeRetry, http.StatusOK}
+	for i, s := range requestSpans {
+		if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("expected span %d to be a child of the resource's span", i)
+		}
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range s.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		if got := attrs["http.response.status_code"].AsInt64(); got != expectedStatuses[i] {
+			t.Errorf("expected span %d to have status %d, got %d", i, expectedStatuses[i], got)
+		}
+		if got := attrs[TraceResourceTypeKey].AsString(); got != "google_widget" {
+			t.Errorf("expected span %d to have resource type google_widget, got %q", i, got)
+		}
+		if got := attrs["url.template"].AsString(); got != "/v1/projects/{}/widgets/{}" {
+			t.Errorf("expected span %d to have URL template, got %q", i, got)
+		}
+	}
+}
+
+func TestTraceResource_keepsCallContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	type callKey struct{}
+	providerCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := &Config{Context: providerCtx}
+
+	called := false
+	r := &schema.Resource{
+		CreateWithoutTimeout: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			called = true
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("expected the call's context to keep its deadline")
+			}
+			if ctx.Value(callKey{}) == nil {
+				t.Errorf("expected the call's context to keep its values")
+			}
+			tracedCtx := meta.(*Config).Context
+			if _, ok := tracedCtx.Deadline(); ok {
+				t.Errorf("expected the provider's context not to take on the call's deadline")
+			}
+			if !trace.SpanFromContext(tracedCtx).SpanContext().Equal(trace.SpanFromContext(ctx).SpanContext()) {
+				t.Errorf("expected the provider's context to carry the call's span")
+			}
+			return nil
+		},
+	}
+	TraceResource("google_widget", r)
+
+	ctx, cancelCall := context.WithTimeout(context.WithValue(context.Background(), callKey{}, true), time.Minute)
+	defer cancelCall()
+	d := r.TestResourceData()
+	if diags := r.CreateWithoutTimeout(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !called {
+		t.Fatal("expected the wrapped function to be called")
+	}
+	if spans := recorder.Ended(); len(spans) != 1 || spans[0].Name() != "Create google_widget" {
+		t.Errorf("expected a span for the call, got %v", spans)
+	}
+}
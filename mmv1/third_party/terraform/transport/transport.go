@@ -27,6 +27,11 @@ type SendRequestOptions struct {
 	Headers              http.Header
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// RetryPolicy overrides the provider's retry policy for this request.
+	RetryPolicy *RetryPolicy
+	// Resource is the type of the Terraform resource the request is for, as
+	// recorded in the provider's audit log.
+	Resource string
 }
 
 func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
@@ -42,11 +47,12 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		// set the header X-Goog-User-Project to be empty string.
 		if opt.Project == "NO_BILLING_PROJECT_OVERRIDE" {
 			reqHeaders.Set("X-Goog-User-Project", "")
-		} else {
+		} else if opt.Config.QuotaProjectRouting.IsEmpty() {
 			// Pass the project into this fn instead of parsing it from the URL because
 			// both project names and URLs can have colons in them.
 			reqHeaders.Set("X-Goog-User-Project", opt.Project)
 		}
+		// Otherwise the header transport routes the request's quota project, passed in its context.
 	}
 
 	if opt.Timeout == 0 {
@@ -54,8 +60,12 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 	}
 
 	var res *http.Response
+	// Set when the retry transport gives up on a request after its policy's max
+	// attempts, in which case the error isn't retried again here.
+	var retriesExhausted bool
 	err := Retry(RetryOptions{
 		RetryFunc: func() error {
+			retriesExhausted = false
 			var buf bytes.Buffer
 			if opt.Body != nil {
 				err := json.NewEncoder(&buf).Encode(opt.Body)
@@ -74,6 +84,13 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 			}
 
 			req.Header = reqHeaders
+			req = req.WithContext(ContextWithRetryPolicy(req.Context(), opt.RetryPolicy))
+			req = req.WithContext(ContextWithRetriesExhausted(req.Context(), &retriesExhausted))
+			req = req.WithContext(ContextWithAuditResource(req.Context(), opt.Resource))
+			req = req.WithContext(ContextWithTraceSpan(req.Context(), opt.Config.Context))
+			if opt.Project != "NO_BILLING_PROJECT_OVERRIDE" {
+				req = req.WithContext(ContextWithQuotaProject(req.Context(), opt.Project))
+			}
 			res, err = opt.Config.Client.Do(req)
 			if err != nil {
 				return err
@@ -88,7 +105,12 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		},
 		Timeout:              opt.Timeout,
 		ErrorRetryPredicates: opt.ErrorRetryPredicates,
-		ErrorAbortPredicates: opt.ErrorAbortPredicates,
+		ErrorAbortPredicates: append([]RetryErrorPredicateFunc{func(error) (bool, string) {
+			if retriesExhausted {
+				return true, "Retry transport reached its retry policy's max attempts"
+			}
+			return false, ""
+		}}, opt.ErrorAbortPredicates...),
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultUniverseDomain is the universe domain of the public Google Cloud APIs,
+// which every default base path is in.
+const DefaultUniverseDomain = "googleapis.com"
+
+// UniverseDomainEndpoint returns endpoint moved from the default universe to
+// universeDomain, e.g. "https://compute.googleapis.com/compute/v1/" becomes
+// "https://compute.example.com/compute/v1/". Endpoints whose host isn't in the
+// default universe, such as most custom endpoints, are returned unchanged.
+func UniverseDomainEndpoint(endpoint, universeDomain string) string {
+	if universeDomain == "" || universeDomain == DefaultUniverseDomain {
+		return endpoint
+	}
+
+	// Endpoints may contain variables such as {{location}} in their host, so
+	// they can't be parsed as URLs.
+	i := strings.Index(endpoint, "://")
+	if i < 0 {
+		return endpoint
+	}
+	hostStart := i + len("://")
+	hostEnd := len(endpoint)
+	if j := strings.Index(endpoint[hostStart:], "/"); j >= 0 {
+		hostEnd = hostStart + j
+	}
+
+	host := endpoint[hostStart:hostEnd]
+	switch {
+	case host == DefaultUniverseDomain:
+		host = universeDomain
+	case strings.HasSuffix(host, "."+DefaultUniverseDomain):
+		host = strings.TrimSuffix(host, DefaultUniverseDomain) + universeDomain
+	default:
+		return endpoint
+	}
+	return endpoint[:hostStart] + host + endpoint[hostEnd:]
+}
+
+// UniverseBasePaths returns DefaultBasePaths resolved for universeDomain, keyed
+// the same way.
+func UniverseBasePaths(universeDomain string) map[string]string {
+	basePaths := make(map[string]string, len(DefaultBasePaths))
+	for key, basePath := range DefaultBasePaths {
+		basePaths[key] = UniverseDomainEndpoint(basePath, universeDomain)
+	}
+	return basePaths
+}
+
+// ValidateUniverseDomain returns an error if configured, the provider's
+// universe_domain, doesn't match credentials, the universe domain of its
+// credentials. Either being unset means the default universe.
+func ValidateUniverseDomain(configured, credentials string) error {
+	if configured != "" {
+		if credentials == "" && configured != DefaultUniverseDomain {
+			return fmt.Errorf("Universe domain mismatch: '%s' supplied directly to Terraform with no matching universe domain in credentials. Credentials with no 'universe_domain' set are assumed to be in the default universe.", configured)
+		} else if configured != credentials && !(credentials == "" && configured == DefaultUniverseDomain) {
+			return fmt.Errorf("Universe domain mismatch: '%s' does not match the universe domain '%s' supplied directly to Terraform. The 'universe_domain' provider configuration must match the universe domain supplied by credentials.", credentials, configured)
+		}
+	} else if credentials != "" && credentials != DefaultUniverseDomain {
+		return fmt.Errorf("Universe domain mismatch: Universe domain '%s' was found in credentials without a corresponding 'universe_domain' provider configuration set. Please set 'universe_domain' to '%s' or use different credentials.", credentials, credentials)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package transport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-google/google/provider"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func TestUniverseDomainEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		Endpoint       string
+		UniverseDomain string
+		Expected       string
+	}{
+		"default universe": {
+			Endpoint:       "https://compute.googleapis.com/compute/v1/",
+			UniverseDomain: "googleapis.com",
+			Expected:       "https://compute.googleapis.com/compute/v1/",
+		},
+		"unset universe": {
+			Endpoint: "https://compute.googleapis.com/compute/v1/",
+			Expected: "https://compute.googleapis.com/compute/v1/",
+		},
+		"universe": {
+			Endpoint:       "https://compute.googleapis.com/compute/v1/",
+			UniverseDomain: "example.com",
+			Expected:       "https://compute.example.com/compute/v1/",
+		},
+		"host variable": {
+			Endpoint:       "https://{{location}}-gkemulticloud.googleapis.com/v1/",
+			UniverseDomain: "example.com",
+			Expected:       "https://{{location}}-gkemulticloud.example.com/v1/",
+		},
+		"mtls": {
+			Endpoint:       "https://compute.mtls.googleapis.com/compute/v1/",
+			UniverseDomain: "example.com",
+			Expected:       "https://compute.mtls.example.com/compute/v1/",
+		},
+		"no path": {
+			Endpoint:       "https://storage.googleapis.com",
+			UniverseDomain: "example.com",
+			Expected:       "https://storage.example.com",
+		},
+		"only the host": {
+			Endpoint:       "https://storage.googleapis.com/storage/v1/b/googleapis.com/",
+			UniverseDomain: "example.com",
+			Expected:       "https://storage.example.com/storage/v1/b/googleapis.com/",
+		},
+		"custom endpoint": {
+			Endpoint:       "https://compute.private.corp/compute/v1/",
+			UniverseDomain: "example.com",
+			Expected:       "https://compute.private.corp/compute/v1/",
+		},
+		"lookalike host": {
+			Endpoint:       "https://notgoogleapis.com/v1/",
+			UniverseDomain: "example.com",
+			Expected:       "https://notgoogleapis.com/v1/",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := transport_tpg.UniverseDomainEndpoint(tc.Endpoint, tc.UniverseDomain); got != tc.Expected {
+				t.Errorf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestUniverseBasePaths(t *testing.T) {
+	basePaths := transport_tpg.UniverseBasePaths("example.com")
+	if len(basePaths) != len(transport_tpg.DefaultBasePaths) {
+		t.Fatalf("expected %d base paths, got %d", len(transport_tpg.DefaultBasePaths), len(basePaths))
+	}
+	for key, defaultBasePath := range transport_tpg.DefaultBasePaths {
+		t.Run(key, func(t *testing.T) {
+			basePath := basePaths[key]
+			if strings.Contains(basePath, "googleapis.com") {
+				t.Errorf("expected %s base path %q to be resolved to example.com", key, basePath)
+			}
+			if expected := strings.Replace(defaultBasePath, "googleapis.com", "example.com", 1); basePath != expected {
+				t.Errorf("expected %s base path %q, got %q", key, expected, basePath)
+			}
+		})
+	}
+
+	for key, basePath := range transport_tpg.UniverseBasePaths("") {
+		if basePath != transport_tpg.DefaultBasePaths[key] {
+			t.Errorf("expected default %s base path %q, got %q", key, transport_tpg.DefaultBasePaths[key], basePath)
+		}
+	}
+}
+
+func TestSetEndpointDefaults_universeDomain(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, provider.Provider().Schema, map[string]interface{}{
+		"universe_domain":         "example.com",
+		"compute_custom_endpoint": "https://compute.private.corp/compute/v1/",
+	})
+
+	if err := transport_tpg.SetEndpointDefaults(d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for k := range provider.Provider().Schema {
+		if !strings.HasSuffix(k, "_custom_endpoint") {
+			continue
+		}
+		if v := d.Get(k).(string); strings.Contains(v, "googleapis.com") {
+			t.Errorf("expected %s %q to be in example.com", k, v)
+		}
+	}
+	if v := d.Get("compute_custom_endpoint").(string); v != "https://compute.private.corp/compute/v1/" {
+		t.Errorf("expected compute_custom_endpoint to be kept, got %q", v)
+	}
+}
+
+func TestConfigureBasePaths_universeDomain(t *testing.T) {
+	config := &transport_tpg.Config{UniverseDomain: "example.com"}
+	transport_tpg.ConfigureBasePaths(config)
+
+	if expected := "https://compute.example.com/compute/v1/"; config.ComputeBasePath != expected {
+		t.Errorf("expected ComputeBasePath %q, got %q", expected, config.ComputeBasePath)
+	}
+	if expected := "https://cloudbilling.example.com/v1/"; config.CloudBillingBasePath != expected {
+		t.Errorf("expected CloudBillingBasePath %q, got %q", expected, config.CloudBillingBasePath)
+	}
+}
+
+func TestValidateUniverseDomain(t *testing.T) {
+	cases := map[string]struct {
+		Configured  string
+		Credentials string
+		ExpectError bool
+	}{
+		"both unset": {},
+		"default configured": {
+			Configured: "googleapis.com",
+		},
+		"default in credentials": {
+			Credentials: "googleapis.com",
+		},
+		"matching": {
+			Configured:  "example.com",
+			Credentials: "example.com",
+		},
+		"unset in credentials": {
+			Configured:  "example.com",
+			ExpectError: true,
+		},
+		"unset in configuration": {
+			Credentials: "example.com",
+			ExpectError: true,
+		},
+		"mismatch": {
+			Configured:  "example.com",
+			Credentials: "other.example.com",
+			ExpectError: true,
+		},
+		"default configured with universe credentials": {
+			Configured:  "googleapis.com",
+			Credentials: "example.com",
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := transport_tpg.ValidateUniverseDomain(tc.Configured, tc.Credentials)
+			if tc.ExpectError && err == nil {
+				t.Errorf("expected error")
+			}
+			if !tc.ExpectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err != nil && !strings.HasPrefix(err.Error(), "Universe domain mismatch") {
+				t.Errorf("unexpected error message %q", err)
+			}
+		})
+	}
+}
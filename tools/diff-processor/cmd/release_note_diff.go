@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	newProvider "google/provider/new/google/provider"
+	oldProvider "google/provider/old/google/provider"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const releaseNoteDiffDesc = `Summarize the difference between the new / old Terraform provider versions for validating release notes.`
+
+// releaseNoteDiff is read by changelog-pr-body-check as a changelog.ProviderDiff.
+type releaseNoteDiff struct {
+	OldResources    []string `json:"old_resources"`
+	NewResources    []string `json:"new_resources"`
+	OldDataSources  []string `json:"old_data_sources"`
+	NewDataSources  []string `json:"new_data_sources"`
+	BreakingChanges []string `json:"breaking_changes"`
+	Services        []string `json:"services"`
+}
+
+type releaseNoteDiffOptions struct {
+	rootOptions      *rootOptions
+	oldResourceMap   func() map[string]*schema.Resource
+	newResourceMap   func() map[string]*schema.Resource
+	oldDatasourceMap func() map[string]*schema.Resource
+	newDatasourceMap func() map[string]*schema.Resource
+	stdout           io.Writer
+}
+
+func newReleaseNoteDiffCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &releaseNoteDiffOptions{
+		rootOptions:      rootOptions,
+		oldResourceMap:   oldProvider.ResourceMap,
+		newResourceMap:   newProvider.ResourceMap,
+		oldDatasourceMap: oldProvider.DatasourceMap,
+		newDatasourceMap: newProvider.DatasourceMap,
+		stdout:           os.Stdout,
+	}
+	return &cobra.Command{
+		Use:   "release-note-diff [SERVICES_DIR]",
+		Short: releaseNoteDiffDesc,
+		Long:  releaseNoteDiffDesc,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run(args)
+		},
+	}
+}
+
+func (o *releaseNoteDiffOptions) run(args []string) error {
+	oldResources := o.oldResourceMap()
+	newResources := o.newResourceMap()
+	schemaDiff := diff.ComputeSchemaDiff(oldResources, newResources)
+
+	out := releaseNoteDiff{
+		OldResources:    sortedKeys(oldResources),
+		NewResources:    sortedKeys(newResources),
+		OldDataSources:  sortedKeys(o.oldDatasourceMap()),
+		NewDataSources:  sortedKeys(o.newDatasourceMap()),
+		BreakingChanges: []string{},
+		Services:        []string{},
+	}
+	for _, bc := range breaking_changes.ComputeBreakingChanges(schemaDiff) {
+		out.BreakingChanges = append(out.BreakingChanges, bc.Message)
+	}
+	sort.Strings(out.BreakingChanges)
+
+	if len(args) > 0 {
+		services, err := readServices(args[0])
+		if err != nil {
+			return err
+		}
+		out.Services = services
+	}
+
+	if err := json.NewEncoder(o.stdout).Encode(out); err != nil {
+		return fmt.Errorf("error encoding json: %w", err)
+	}
+	return nil
+}
+
+// readServices returns the names of the service packages in servicesDir, along with
+// the API subdomain of each resource in them, since both are used as release note
+// service prefixes.
+func readServices(servicesDir string) ([]string, error) {
+	entries, err := os.ReadDir(servicesDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading services directory: %w", err)
+	}
+	services := make(map[string]struct{})
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		services[entry.Name()] = struct{}{}
+		paths, err := filepath.Glob(filepath.Join(servicesDir, entry.Name(), "*_meta.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("error listing metadata files: %w", err)
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %w", path, err)
+			}
+			var metadata struct {
+				ApiServiceName string `yaml:"api_service_name"`
+			}
+			if err := yaml.Unmarshal(data, &metadata); err != nil {
+				return nil, fmt.Errorf("error unmarshalling %s: %w", path, err)
+			}
+			if subdomain, _, _ := strings.Cut(metadata.ApiServiceName, "."); subdomain != "" {
+				services[subdomain] = struct{}{}
+			}
+		}
+	}
+	keys := maps.Keys(services)
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func sortedKeys(m map[string]*schema.Resource) []string {
+	keys := maps.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReleaseNoteDiffCmd(t *testing.T) {
+	servicesDir := t.TempDir()
+	for _, service := range []string{"compute", "sql"} {
+		if err := os.Mkdir(filepath.Join(servicesDir, service), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(servicesDir, "README.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := "resource: 'google_sql_user'\napi_service_name: 'sqladmin.googleapis.com'\n"
+	if err := os.WriteFile(filepath.Join(servicesDir, "sql", "resource_sql_user_meta.yaml"), []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceMap := func(m map[string]*schema.Resource) func() map[string]*schema.Resource {
+		return func() map[string]*schema.Resource { return m }
+	}
+	var buf bytes.Buffer
+	o := releaseNoteDiffOptions{
+		oldResourceMap: resourceMap(map[string]*schema.Resource{
+			"google_x": {
+				Schema: map[string]*schema.Schema{
+					"field-a": {Description: "beep", Optional: true},
+				},
+			},
+		}),
+		newResourceMap: resourceMap(map[string]*schema.Resource{
+			"google_x": {
+				Schema: map[string]*schema.Schema{
+					"field-a": {Description: "beep", Required: true},
+				},
+			},
+			"google_y": {
+				Schema: map[string]*schema.Schema{
+					"field-a": {Description: "beep", Optional: true},
+				},
+			},
+		}),
+		oldDatasourceMap: resourceMap(map[string]*schema.Resource{}),
+		newDatasourceMap: resourceMap(map[string]*schema.Resource{"google_y": {}}),
+		stdout:           &buf,
+	}
+
+	if err := o.run([]string{servicesDir}); err != nil {
+		t.Fatalf("Error running command: %s", err)
+	}
+
+	var got releaseNoteDiff
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to unmarshall output: %s", err)
+	}
+	if len(got.BreakingChanges) != 1 {
+		t.Errorf("Unexpected number of breaking changes. Want 1, got %d: %v", len(got.BreakingChanges), got.BreakingChanges)
+	}
+	got.BreakingChanges = nil
+	want := releaseNoteDiff{
+		OldResources:   []string{"google_x"},
+		NewResources:   []string{"google_x", "google_y"},
+		OldDataSources: []string{},
+		NewDataSources: []string{"google_y"},
+		Services:       []string{"compute", "sql", "sqladmin"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected output (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hashicorp/go-changelog"
+)
+
+func main() {
+	var repo, betaRepo, lastRelease, thisRelease, entriesDir, format, linkFormat, betaLinkFormat string
+	flag.StringVar(&repo, "repo", "", "URL of the repository to build release notes for")
+	flag.StringVar(&betaRepo, "beta-repo", "", "URL of a second repository, such as the beta provider, whose notes are merged into the release notes")
+	flag.StringVar(&lastRelease, "last-release", "", "git ref of the last release, or - to include all entries")
+	flag.StringVar(&thisRelease, "this-release", "", "git ref of the release to build notes for")
+	flag.StringVar(&entriesDir, "entries-dir", ".changelog", "directory containing changelog entries")
+	flag.StringVar(&format, "format", "markdown", "output format, either markdown or json")
+	flag.StringVar(&linkFormat, "link-format", "https://github.com/hashicorp/terraform-provider-google/pull/%s", "format of links to issues in -repo, with the issue number substituted for %s")
+	flag.StringVar(&betaLinkFormat, "beta-link-format", "https://github.com/hashicorp/terraform-provider-google-beta/pull/%s", "format of links to issues in -beta-repo, with the issue number substituted for %s")
+	flag.Parse()
+
+	if repo == "" {
+		log.Fatalf("-repo not set")
+	}
+	if lastRelease == "" {
+		log.Fatalf("-last-release not set")
+	}
+	if thisRelease == "" {
+		log.Fatalf("-this-release not set")
+	}
+	if format != "markdown" && format != "json" {
+		log.Fatalf("Unknown format %q, must be markdown or json", format)
+	}
+
+	var notes []changelog.Note
+	for provider, r := range map[string]string{"": repo, "beta": betaRepo} {
+		if r == "" {
+			continue
+		}
+		entries, err := changelog.Diff(r, lastRelease, thisRelease, entriesDir)
+		if err != nil {
+			log.Fatalf("Error computing changelog entries for %s from %s to %s: %s", r, lastRelease, thisRelease, err)
+		}
+		for _, note := range changelog.NotesFromEntryList(entries) {
+			note.Provider = provider
+			notes = append(notes, note)
+		}
+	}
+
+	releaseNotes := changelog.BuildReleaseNotes(notes)
+	switch format {
+	case "markdown":
+		fmt.Print(releaseNotes.Markdown(map[string]string{"": linkFormat, "beta": betaLinkFormat}))
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(releaseNotes); err != nil {
+			log.Fatalf("Error encoding release notes: %s", err)
+		}
+	}
+}
@@ -5,6 +5,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -17,10 +19,13 @@ import (
 
 func main() {
 	ctx := context.Background()
-	if len(os.Args) < 2 {
-		log.Fatalf("Usage: changelog-pr-body-check PR#\n")
+	var diffPath string
+	flag.StringVar(&diffPath, "diff", "", "path to the output of `diff-processor release-note-diff` for the PR, used to check entries against the provider changes")
+	flag.Parse()
+	if flag.NArg() < 1 {
+		log.Fatalf("Usage: changelog-pr-body-check [-diff FILE] PR#\n")
 	}
-	pr := os.Args[1]
+	pr := flag.Arg(0)
 	prNo, err := strconv.Atoi(pr)
 	if err != nil {
 		log.Fatalf("Error parsing PR %q as a number: %s", pr, err)
@@ -58,7 +63,20 @@ func main() {
 		Body:  pullRequest.GetBody(),
 	}
 
-	if errors := entry.Validate(); errors != nil {
+	errors := entry.Validate()
+	if diffPath != "" && len(errors) == 0 {
+		data, err := os.ReadFile(diffPath)
+		if err != nil {
+			log.Fatalf("Error reading provider diff %s: %s", diffPath, err)
+		}
+		var providerDiff changelog.ProviderDiff
+		if err := json.Unmarshal(data, &providerDiff); err != nil {
+			log.Fatalf("Error parsing provider diff %s: %s", diffPath, err)
+		}
+		errors = changelog.ValidateAgainstDiff(changelog.NotesFromEntry(entry), providerDiff)
+	}
+
+	if len(errors) > 0 {
 		body := "\nOops! Some errors are detected for your changelog entries:\n"
 		for i, err := range errors {
 			body += fmt.Sprintf("\n* Issue %d\n", i+1)
@@ -77,6 +95,14 @@ func main() {
 				body += "- Invalid resource/datasource format\nPlease follow format in https://googlecloudplatform.github.io/magic-modules/contribute/release-notes/#type-specific-guidelines-and-examples.\n\n"
 			case changelog.EntryErrorInvalidEnhancementOrBugFixFormat:
 				body += "- Invalid enhancement/bug fix format\nPlease follow format in https://googlecloudplatform.github.io/magic-modules/contribute/release-notes/#type-specific-guidelines-and-examples.\n\n"
+			case changelog.EntryErrorUnknownResource:
+				body += "- Unknown resource or datasource\nPlease check that backticked resources and datasources exist in the provider.\n\n"
+			case changelog.EntryErrorResourceAlreadyExists:
+				body += "- Resource or datasource already exists\nPlease use `enhancement` for changes to existing resources and datasources.\n\n"
+			case changelog.EntryErrorMissingBreakingChange:
+				body += "- Missing breaking change\n" + err.Error() + "\nPlease add a `breaking-change` entry, as described in https://googlecloudplatform.github.io/magic-modules/contribute/release-notes/.\n\n"
+			case changelog.EntryErrorUnknownService:
+				body += "- Unknown service\n" + err.Error() + "\n\n"
 			}
 		}
 		log.Fatal(body)
@@ -40,6 +40,10 @@ const (
 	EntryErrorInvalidNewReourceOrDatasourceFormat EntryErrorCode = "INVALID_NEW_RESOURCE_OR_DATASOURCE_FORMAT"
 	EntryErrorMultipleLines                       EntryErrorCode = "MULTIPLE_LINES"
 	EntryErrorInvalidEnhancementOrBugFixFormat    EntryErrorCode = "INVALID_ENHANCEMENT_OR_BUGFIX_FORMAT"
+	EntryErrorUnknownResource                     EntryErrorCode = "UNKNOWN_RESOURCE"
+	EntryErrorResourceAlreadyExists               EntryErrorCode = "RESOURCE_ALREADY_EXISTS"
+	EntryErrorMissingBreakingChange               EntryErrorCode = "MISSING_BREAKING_CHANGE"
+	EntryErrorUnknownService                      EntryErrorCode = "UNKNOWN_SERVICE"
 )
 
 type EntryValidationError struct {
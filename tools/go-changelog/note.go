@@ -17,6 +17,9 @@ type Note struct {
 	Issue string
 	Hash  string
 	Date  time.Time
+	// Provider names the provider the note's entry was found in, for when
+	// notes from several providers are combined.
+	Provider string
 }
 
 var TypeValues = []string{
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ProviderDiff summarises the provider changes made by a PR, as output by
+// `diff-processor release-note-diff`. Empty lists are treated as unknown and
+// skip the checks that depend on them.
+type ProviderDiff struct {
+	OldResources    []string `json:"old_resources"`
+	NewResources    []string `json:"new_resources"`
+	OldDataSources  []string `json:"old_data_sources"`
+	NewDataSources  []string `json:"new_data_sources"`
+	BreakingChanges []string `json:"breaking_changes"`
+	Services        []string `json:"services"`
+}
+
+// Service prefixes that are valid for changes that don't belong to a single service.
+// IAM resources are spread across services, and the IAM services themselves live in
+// packages such as iam2 and iambeta, so "iam" doesn't match any service package.
+var generalServices = []string{"provider", "iam"}
+
+var backtickedResourceRegexp = regexp.MustCompile("`(google_[a-z0-9_]+)`")
+
+// ValidateAgainstDiff checks that the notes of a PR are consistent with the
+// provider changes it makes.
+func ValidateAgainstDiff(notes []Note, d ProviderDiff) []*EntryValidationError {
+	var errors []*EntryValidationError
+
+	hasBreakingChange := false
+	for _, note := range notes {
+		if note.Type == "breaking-change" {
+			hasBreakingChange = true
+		}
+
+		// Resources and datasources that are removed or renamed by the PR only exist in
+		// the old provider, so notes may mention those of either provider.
+		if len(d.NewResources) > 0 || len(d.NewDataSources) > 0 {
+			for _, match := range backtickedResourceRegexp.FindAllStringSubmatch(note.Body, -1) {
+				if !containsString(d.NewResources, match[1]) && !containsString(d.NewDataSources, match[1]) &&
+					!containsString(d.OldResources, match[1]) && !containsString(d.OldDataSources, match[1]) {
+					errors = append(errors, noteError(note, EntryErrorUnknownResource,
+						fmt.Sprintf("unknown resource or datasource %s in changelog entry %v", match[1], note.Body)))
+				}
+			}
+		}
+
+		var existing []string
+		switch note.Type {
+		case "new-resource":
+			existing = d.OldResources
+		case "new-datasource":
+			existing = d.OldDataSources
+		}
+		for _, match := range backtickedResourceRegexp.FindAllStringSubmatch(note.Body, -1) {
+			if containsString(existing, match[1]) {
+				errors = append(errors, noteError(note, EntryErrorResourceAlreadyExists,
+					fmt.Sprintf("%s already exists but changelog entry %v is of type %s", match[1], note.Body, note.Type)))
+			}
+		}
+
+		if (note.Type == "enhancement" || note.Type == "bug") && len(d.Services) > 0 {
+			service := serviceOf(note.Body)
+			if service != "" && !containsString(d.Services, service) && !containsString(generalServices, service) {
+				errors = append(errors, noteError(note, EntryErrorUnknownService,
+					fmt.Sprintf("unknown service %s in changelog entry %v: please use one of %s", service, note.Body, strings.Join(d.Services, ", "))))
+			}
+		}
+	}
+
+	if len(d.BreakingChanges) > 0 && !hasBreakingChange {
+		errors = append(errors, &EntryValidationError{
+			message: fmt.Sprintf("breaking changes were detected but there is no breaking-change changelog entry: %s", strings.Join(d.BreakingChanges, "; ")),
+			Code:    EntryErrorMissingBreakingChange,
+		})
+	}
+
+	return errors
+}
+
+func noteError(note Note, code EntryErrorCode, message string) *EntryValidationError {
+	return &EntryValidationError{
+		message: message,
+		Code:    code,
+		Details: map[string]interface{}{
+			"type": note.Type,
+			"note": note.Body,
+		},
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateAgainstDiff(t *testing.T) {
+	providerDiff := ProviderDiff{
+		OldResources:   []string{"google_compute_instance", "google_compute_instance_old"},
+		NewResources:   []string{"google_compute_instance", "google_compute_disk"},
+		OldDataSources: []string{"google_compute_instance"},
+		NewDataSources: []string{"google_compute_instance"},
+		Services:       []string{"compute", "sql"},
+	}
+	cases := map[string]struct {
+		notes         []Note
+		providerDiff  ProviderDiff
+		expectedCodes []EntryErrorCode
+	}{
+		"valid notes": {
+			notes: []Note{
+				{Type: "new-resource", Body: "`google_compute_disk`"},
+				{Type: "enhancement", Body: "compute: added `foo` field to `google_compute_instance` resource"},
+				{Type: "bug", Body: "provider: fixed a crash"},
+			},
+			providerDiff: providerDiff,
+		},
+		"removed resource": {
+			notes: []Note{
+				{Type: "breaking-change", Body: "compute: removed `google_compute_instance_old` resource"},
+			},
+			providerDiff: providerDiff,
+		},
+		"iam service": {
+			notes: []Note{
+				{Type: "enhancement", Body: "iam: added `condition` field to `google_compute_instance` IAM resources"},
+			},
+			providerDiff: providerDiff,
+		},
+		"unknown resource": {
+			notes: []Note{
+				{Type: "enhancement", Body: "compute: added `foo` field to `google_compute_instances` resource"},
+			},
+			providerDiff:  providerDiff,
+			expectedCodes: []EntryErrorCode{EntryErrorUnknownResource},
+		},
+		"new resource already exists": {
+			notes: []Note{
+				{Type: "new-resource", Body: "`google_compute_instance`"},
+			},
+			providerDiff:  providerDiff,
+			expectedCodes: []EntryErrorCode{EntryErrorResourceAlreadyExists},
+		},
+		"new datasource already exists": {
+			notes: []Note{
+				{Type: "new-datasource", Body: "`google_compute_instance`"},
+			},
+			providerDiff:  providerDiff,
+			expectedCodes: []EntryErrorCode{EntryErrorResourceAlreadyExists},
+		},
+		"unknown service": {
+			notes: []Note{
+				{Type: "bug", Body: "compte: fixed a crash"},
+			},
+			providerDiff:  providerDiff,
+			expectedCodes: []EntryErrorCode{EntryErrorUnknownService},
+		},
+		"missing breaking change": {
+			notes: []Note{
+				{Type: "enhancement", Body: "sql: made `foo` required"},
+			},
+			providerDiff: ProviderDiff{
+				BreakingChanges: []string{"Field `foo` changed from optional to required on `google_sql_database_instance`"},
+			},
+			expectedCodes: []EntryErrorCode{EntryErrorMissingBreakingChange},
+		},
+		"breaking change present": {
+			notes: []Note{
+				{Type: "breaking-change", Body: "sql: made `foo` required"},
+			},
+			providerDiff: ProviderDiff{
+				BreakingChanges: []string{"Field `foo` changed from optional to required on `google_sql_database_instance`"},
+			},
+		},
+		"empty diff skips checks": {
+			notes: []Note{
+				{Type: "enhancement", Body: "compte: added `foo` field to `google_foo` resource"},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			var codes []EntryErrorCode
+			for _, err := range ValidateAgainstDiff(tc.notes, tc.providerDiff) {
+				codes = append(codes, err.Code)
+			}
+			if !reflect.DeepEqual(codes, tc.expectedCodes) {
+				t.Errorf("expected error codes %v, got %v", tc.expectedCodes, codes)
+			}
+		})
+	}
+}
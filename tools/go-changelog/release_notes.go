@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SectionTypes lists the note types that are rendered in release notes, in the
+// order their sections appear.
+var SectionTypes = []string{
+	"breaking-change",
+	"note",
+	"deprecation",
+	"new-resource",
+	"new-datasource",
+	"enhancement",
+	"bug",
+}
+
+var sectionTitles = map[string]string{
+	"breaking-change": "BREAKING CHANGES",
+	"note":            "NOTES",
+	"deprecation":     "DEPRECATIONS",
+	"new-resource":    "FEATURES",
+	"new-datasource":  "FEATURES",
+	"enhancement":     "IMPROVEMENTS",
+	"bug":             "BUG FIXES",
+}
+
+// ReleaseNotes are the notes of a release, grouped into sections by type.
+type ReleaseNotes struct {
+	Sections []Section `json:"sections"`
+}
+
+// Section holds the notes of a single type. Enhancements and bug fixes are
+// further grouped by the service prefix of their body, e.g. "compute: ...".
+type Section struct {
+	Type   string  `json:"type"`
+	Title  string  `json:"title"`
+	Groups []Group `json:"groups"`
+}
+
+// Group holds the notes for one service within a section. Service is empty
+// for sections that are not grouped by service.
+type Group struct {
+	Service string        `json:"service,omitempty"`
+	Notes   []ReleaseNote `json:"notes"`
+}
+
+// ReleaseNote is a note together with every issue it was found in.
+type ReleaseNote struct {
+	Body   string  `json:"body"`
+	Issues []Issue `json:"issues"`
+}
+
+// Issue is the issue a note was found in, along with the provider whose
+// repository the issue belongs to.
+type Issue struct {
+	Provider string `json:"provider,omitempty"`
+	ID       string `json:"id"`
+}
+
+// NotesFromEntryList returns the notes of every entry in el.
+func NotesFromEntryList(el *EntryList) []Note {
+	var res []Note
+	for i := 0; i < el.Len(); i++ {
+		if e := el.Get(i); e != nil {
+			res = append(res, NotesFromEntry(*e)...)
+		}
+	}
+	return res
+}
+
+// BuildReleaseNotes groups notes into sections. Notes of types not in
+// SectionTypes, such as "none", are dropped, and notes with the same type and
+// body, such as a note that appears in both the GA and beta providers, are
+// merged into one note listing all of their issues.
+func BuildReleaseNotes(notes []Note) ReleaseNotes {
+	byType := make(map[string]map[string]*ReleaseNote)
+	for _, note := range notes {
+		body := strings.TrimSpace(note.Body)
+		if _, ok := sectionTitles[note.Type]; !ok || body == "" {
+			continue
+		}
+		if byType[note.Type] == nil {
+			byType[note.Type] = make(map[string]*ReleaseNote)
+		}
+		rn, ok := byType[note.Type][body]
+		if !ok {
+			rn = &ReleaseNote{Body: body}
+			byType[note.Type][body] = rn
+		}
+		issue := Issue{Provider: note.Provider, ID: note.Issue}
+		if note.Issue != "" && !containsIssue(rn.Issues, issue) {
+			rn.Issues = append(rn.Issues, issue)
+			sort.Slice(rn.Issues, func(i, j int) bool {
+				if rn.Issues[i].Provider != rn.Issues[j].Provider {
+					return rn.Issues[i].Provider < rn.Issues[j].Provider
+				}
+				return rn.Issues[i].ID < rn.Issues[j].ID
+			})
+		}
+	}
+
+	var rn ReleaseNotes
+	for _, typ := range SectionTypes {
+		if len(byType[typ]) == 0 {
+			continue
+		}
+		groups := make(map[string][]ReleaseNote)
+		for body, note := range byType[typ] {
+			service := ""
+			if typ == "enhancement" || typ == "bug" {
+				service = serviceOf(body)
+			}
+			groups[service] = append(groups[service], *note)
+		}
+		section := Section{Type: typ, Title: sectionTitles[typ]}
+		for service, notes := range groups {
+			sort.Slice(notes, func(i, j int) bool {
+				return notes[i].Body < notes[j].Body
+			})
+			section.Groups = append(section.Groups, Group{Service: service, Notes: notes})
+		}
+		sort.Slice(section.Groups, func(i, j int) bool {
+			return section.Groups[i].Service < section.Groups[j].Service
+		})
+		rn.Sections = append(rn.Sections, section)
+	}
+	return rn
+}
+
+// Markdown renders the release notes in the format used by CHANGELOG.md.
+// linkFormats maps the provider of each issue to the format used to link to
+// it, with the issue number substituted for %s. Issues of providers without a
+// link format are not linked.
+func (rn ReleaseNotes) Markdown(linkFormats map[string]string) string {
+	var sb strings.Builder
+	lastTitle := ""
+	for _, section := range rn.Sections {
+		if section.Title != lastTitle {
+			if lastTitle != "" {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "%s:\n", section.Title)
+			lastTitle = section.Title
+		}
+		for _, group := range section.Groups {
+			for _, note := range group.Notes {
+				fmt.Fprintf(&sb, "* %s%s", notePrefix(section.Type), note.Body)
+				var links []string
+				for _, issue := range note.Issues {
+					number := strings.TrimSuffix(issue.ID, ".txt")
+					linkFormat, ok := linkFormats[issue.Provider]
+					if !ok {
+						links = append(links, "#"+number)
+						continue
+					}
+					links = append(links, fmt.Sprintf("[#%s](%s)", number, fmt.Sprintf(linkFormat, number)))
+				}
+				if len(links) > 0 {
+					fmt.Fprintf(&sb, " (%s)", strings.Join(links, ", "))
+				}
+				sb.WriteString("\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+func notePrefix(typ string) string {
+	switch typ {
+	case "new-resource":
+		return "**New Resource:** "
+	case "new-datasource":
+		return "**New Data Source:** "
+	}
+	return ""
+}
+
+// serviceOf returns the service prefix of an enhancement or bug fix body,
+// e.g. "compute" for "compute: added `foo` field".
+func serviceOf(body string) string {
+	if !enhancementOrBugFixRegexp.MatchString(body) {
+		return ""
+	}
+	return body[:strings.Index(body, ":")]
+}
+
+func containsIssue(s []Issue, v Issue) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
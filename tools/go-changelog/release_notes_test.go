@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildReleaseNotes(t *testing.T) {
+	notes := []Note{
+		{Type: "enhancement", Body: "compute: added `foo` field to `google_compute_instance` resource", Issue: "2.txt"},
+		{Type: "enhancement", Body: "compute: added `foo` field to `google_compute_instance` resource", Issue: "2.txt"},
+		{Type: "enhancement", Body: "bigquery: added `bar` field to `google_bigquery_table` resource", Issue: "1.txt"},
+		{Type: "bug", Body: "compute: fixed a permadiff on `baz`", Issue: "3.txt"},
+		{Type: "new-resource", Body: "`google_foo_bar`", Issue: "4.txt"},
+		{Type: "breaking-change", Body: "sql: removed `qux` field", Issue: "5.txt"},
+		{Type: "none", Body: "", Issue: "6.txt"},
+		{Type: "deprecation", Body: "storage: deprecated `quux` field", Issue: "7.txt"},
+		{Type: "enhancement", Body: "compute: added `foo` field to `google_compute_instance` resource", Issue: "8.txt"},
+	}
+	expected := ReleaseNotes{
+		Sections: []Section{
+			{
+				Type:  "breaking-change",
+				Title: "BREAKING CHANGES",
+				Groups: []Group{
+					{Notes: []ReleaseNote{{Body: "sql: removed `qux` field", Issues: []Issue{{ID: "5.txt"}}}}},
+				},
+			},
+			{
+				Type:  "deprecation",
+				Title: "DEPRECATIONS",
+				Groups: []Group{
+					{Notes: []ReleaseNote{{Body: "storage: deprecated `quux` field", Issues: []Issue{{ID: "7.txt"}}}}},
+				},
+			},
+			{
+				Type:  "new-resource",
+				Title: "FEATURES",
+				Groups: []Group{
+					{Notes: []ReleaseNote{{Body: "`google_foo_bar`", Issues: []Issue{{ID: "4.txt"}}}}},
+				},
+			},
+			{
+				Type:  "enhancement",
+				Title: "IMPROVEMENTS",
+				Groups: []Group{
+					{Service: "bigquery", Notes: []ReleaseNote{{Body: "bigquery: added `bar` field to `google_bigquery_table` resource", Issues: []Issue{{ID: "1.txt"}}}}},
+					{Service: "compute", Notes: []ReleaseNote{{Body: "compute: added `foo` field to `google_compute_instance` resource", Issues: []Issue{{ID: "2.txt"}, {ID: "8.txt"}}}}},
+				},
+			},
+			{
+				Type:  "bug",
+				Title: "BUG FIXES",
+				Groups: []Group{
+					{Service: "compute", Notes: []ReleaseNote{{Body: "compute: fixed a permadiff on `baz`", Issues: []Issue{{ID: "3.txt"}}}}},
+				},
+			},
+		},
+	}
+	if got := BuildReleaseNotes(notes); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestReleaseNotesMarkdown(t *testing.T) {
+	notes := []Note{
+		{Type: "new-resource", Body: "`google_foo_bar`", Issue: "4.txt"},
+		{Type: "new-datasource", Body: "`google_foo_baz`", Issue: "5.txt"},
+		{Type: "enhancement", Body: "compute: added `foo` field", Issue: "2.txt"},
+		{Type: "enhancement", Body: "compute: added `foo` field", Issue: "3.txt"},
+		{Type: "bug", Body: "sql: fixed a crash", Issue: "1.txt"},
+		{Type: "bug", Body: "sql: fixed a crash", Issue: "1.txt", Provider: "beta"},
+		{Type: "bug", Body: "sql: fixed a crash", Issue: "6.txt", Provider: "alpha"},
+	}
+	expected := "FEATURES:\n" +
+		"* **New Resource:** `google_foo_bar` ([#4](https://example.com/4))\n" +
+		"* **New Data Source:** `google_foo_baz` ([#5](https://example.com/5))\n" +
+		"\n" +
+		"IMPROVEMENTS:\n" +
+		"* compute: added `foo` field ([#2](https://example.com/2), [#3](https://example.com/3))\n" +
+		"\n" +
+		"BUG FIXES:\n" +
+		"* sql: fixed a crash ([#1](https://example.com/1), #6, [#1](https://beta.example.com/1))\n"
+	linkFormats := map[string]string{
+		"":     "https://example.com/%s",
+		"beta": "https://beta.example.com/%s",
+	}
+	if got := BuildReleaseNotes(notes).Markdown(linkFormats); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
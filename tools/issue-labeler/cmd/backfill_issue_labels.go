@@ -26,12 +26,13 @@ import (
 
 var (
 	// used for flags
-	backfillSince  string
-	backfillDryRun bool
+	backfillSince        string
+	backfillDryRun       bool
+	backfillProviderPath string
 )
 
 var backfillIssueLabels = &cobra.Command{
-	Use:   "backfill-issue-labels [--dry-run] [--since=1973-01-01]",
+	Use:   "backfill-issue-labels [--dry-run] [--since=1973-01-01] [--provider-path=path/to/terraform-provider-google]",
 	Short: "Backfills labels on old issues",
 	Long:  "Backfills labels on old issues",
 	Args:  cobra.NoArgs,
@@ -46,9 +47,9 @@ var backfillIssueLabels = &cobra.Command{
 }
 
 func execBackfillIssueLabels() error {
-	regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+	regexpLabels, err := buildRegexLabels(backfillProviderPath)
 	if err != nil {
-		return fmt.Errorf("building regex labels: %w", err)
+		return err
 	}
 	repository := "hashicorp/terraform-provider-google"
 	issues, err := labeler.GetIssues(repository, backfillSince)
@@ -67,4 +68,5 @@ func init() {
 	rootCmd.AddCommand(backfillIssueLabels)
 	backfillIssueLabels.Flags().BoolVar(&backfillDryRun, "dry-run", false, "Only log write actions instead of updating issues")
 	backfillIssueLabels.Flags().StringVar(&backfillSince, "since", "1973-01-01", "Only apply labels to issues filed after given date")
+	backfillIssueLabels.Flags().StringVar(&backfillProviderPath, "provider-path", "", "Path to a generated provider used to derive labels for resources not in enrolled_teams.yml")
 }
@@ -26,8 +26,13 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
 )
 
+var (
+	// used for flags
+	computeProviderPath string
+)
+
 var computeNewLabels = &cobra.Command{
-	Use:   "compute-new-labels",
+	Use:   "compute-new-labels [--provider-path=path/to/terraform-provider-google]",
 	Short: "Computes labels that should be added to an issue based on its body",
 	Long:  "Computes labels that should be added to an issue based on its body",
 	Args:  cobra.NoArgs,
@@ -37,9 +42,9 @@ var computeNewLabels = &cobra.Command{
 }
 
 func execComputeNewLabels() error {
-	regexpLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+	regexpLabels, err := buildRegexLabels(computeProviderPath)
 	if err != nil {
-		return fmt.Errorf("building regex labels: %w", err)
+		return err
 	}
 	issueBody := os.Getenv("ISSUE_BODY")
 	affectedResources := labeler.ExtractAffectedResources(issueBody)
@@ -53,6 +58,25 @@ func execComputeNewLabels() error {
 	return nil
 }
 
+// buildRegexLabels returns the labels in enrolled_teams.yml and, if providerPath is set, the labels
+// derived from the metadata of the provider checked out at providerPath.
+func buildRegexLabels(providerPath string) ([]labeler.RegexpLabel, error) {
+	var metadata []labeler.ResourceMetadata
+	if providerPath != "" {
+		var err error
+		metadata, err = labeler.LoadResourceMetadata(providerPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading provider metadata: %w", err)
+		}
+	}
+	regexpLabels, err := labeler.BuildLabels(labeler.EnrolledTeamsYaml, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("building regex labels: %w", err)
+	}
+	return regexpLabels, nil
+}
+
 func init() {
 	rootCmd.AddCommand(computeNewLabels)
+	computeNewLabels.Flags().StringVar(&computeProviderPath, "provider-path", "", "Path to a generated provider used to derive labels for resources not in enrolled_teams.yml")
 }
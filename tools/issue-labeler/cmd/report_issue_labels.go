@@ -0,0 +1,125 @@
+/*
+* Copyright 2024 Google LLC. All Rights Reserved.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/issue-labeler/labeler"
+)
+
+var (
+	// used for flags
+	reportSince          string
+	reportFormat         string
+	reportOutput         string
+	reportProviderPath   string
+	reportCoverageOutput string
+)
+
+var reportIssueLabels = &cobra.Command{
+	Use:   "report-issue-labels [--format=csv|json] [--output=report.csv] [--since=1973-01-01] [--provider-path=path/to/terraform-provider-google] [--coverage-output=coverage.csv]",
+	Short: "Reports current and computed labels for open issues without updating them",
+	Long: `Reports, for every open issue, its current labels, the labels backfill-issue-labels would set on it, the
+regex that matched each affected resource and the resources that no regex matched.
+
+If --provider-path and --coverage-output are set, also reports the resources in the provider that no
+regex in enrolled_teams.yml matches.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, ok := os.LookupEnv("GITHUB_TOKEN")
+		if !ok {
+			return fmt.Errorf("did not provide GITHUB_TOKEN environment variable")
+		}
+		if reportFormat != "csv" && reportFormat != "json" {
+			return fmt.Errorf("unknown report format %q, must be csv or json", reportFormat)
+		}
+		if reportCoverageOutput != "" && reportProviderPath == "" {
+			return fmt.Errorf("--coverage-output requires --provider-path")
+		}
+		return execReportIssueLabels()
+	},
+}
+
+func execReportIssueLabels() error {
+	regexpLabels, err := buildRegexLabels(reportProviderPath)
+	if err != nil {
+		return err
+	}
+	repository := "hashicorp/terraform-provider-google"
+	issues, err := labeler.GetIssues(repository, reportSince)
+	if err != nil {
+		return fmt.Errorf("getting github issues: %w", err)
+	}
+	reports := labeler.ComputeIssueReports(issues, regexpLabels)
+	if err := writeReport(reportOutput, func(w io.Writer) error {
+		return labeler.WriteIssueReports(w, reports, reportFormat)
+	}); err != nil {
+		return err
+	}
+
+	if reportCoverageOutput == "" {
+		return nil
+	}
+	// Coverage is only checked against the resources listed in enrolled_teams.yml. Labels
+	// derived from metadata are also resolved from enrolled_teams.yml, but only by service.
+	enrolledLabels, err := labeler.BuildRegexLabels(labeler.EnrolledTeamsYaml)
+	if err != nil {
+		return fmt.Errorf("building regex labels: %w", err)
+	}
+	metadata, err := labeler.LoadResourceMetadata(reportProviderPath)
+	if err != nil {
+		return fmt.Errorf("loading provider metadata: %w", err)
+	}
+	var resources []string
+	for _, m := range metadata {
+		resources = append(resources, m.Resource)
+	}
+	coverage := labeler.ComputeCoverage(resources, enrolledLabels)
+	fmt.Fprintf(os.Stderr, "%d / %d provider resources are matched by enrolled_teams.yml\n", coverage.MatchedResources, coverage.TotalResources)
+	return writeReport(reportCoverageOutput, func(w io.Writer) error {
+		return labeler.WriteCoverage(w, coverage, reportFormat)
+	})
+}
+
+// writeReport calls write with the file at path, or stdout if path is empty.
+func writeReport(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(reportIssueLabels)
+	reportIssueLabels.Flags().StringVar(&reportSince, "since", "1973-01-01", "Only report issues filed after given date")
+	reportIssueLabels.Flags().StringVar(&reportFormat, "format", "csv", "Report format, either csv or json")
+	reportIssueLabels.Flags().StringVar(&reportOutput, "output", "", "File to write the issue report to (defaults to stdout)")
+	reportIssueLabels.Flags().StringVar(&reportProviderPath, "provider-path", "", "Path to a generated provider used to derive labels for resources not in enrolled_teams.yml")
+	reportIssueLabels.Flags().StringVar(&reportCoverageOutput, "coverage-output", "", "File to write the resource coverage report to")
+}
@@ -15,6 +15,13 @@ var sectionRegexp = regexp.MustCompile(`### (New or )?Affected Resource\(s\)[^#]
 var commentRegexp = regexp.MustCompile(`<!--.*?-->`)
 var resourceRegexp = regexp.MustCompile(`google_[\w*.]+`)
 
+// Matches resources and data sources declared in HCL, e.g. resource "google_compute_instance" "default".
+var hclBlockRegexp = regexp.MustCompile(`(?:resource|data)\s+"(google_\w+)"`)
+
+// Matches resource addresses in HCL expressions and Terraform errors, e.g. "with google_compute_instance.default,"
+// or data.google_compute_network.default.id.
+var resourceAddressRegexp = regexp.MustCompile(`\b(google_\w+)\.[a-zA-Z_][\w-]*`)
+
 var (
 	//go:embed enrolled_teams.yml
 	EnrolledTeamsYaml []byte
@@ -54,14 +61,30 @@ func BuildRegexLabels(teamsYaml []byte) ([]RegexpLabel, error) {
 	return regexpLabels, nil
 }
 
+// ExtractAffectedResources returns the resources listed in the "Affected Resource(s)" section of an
+// issue body, followed by any other resources declared or referenced in HCL snippets and error messages.
 func ExtractAffectedResources(body string) []string {
+	body = commentRegexp.ReplaceAllString(body, "")
+	resources := []string{}
+	seen := make(map[string]struct{})
+	add := func(resource string) {
+		if _, ok := seen[resource]; !ok {
+			seen[resource] = struct{}{}
+			resources = append(resources, resource)
+		}
+	}
+
 	section := sectionRegexp.FindString(body)
-	section = commentRegexp.ReplaceAllString(section, "")
-	if section != "" {
-		return resourceRegexp.FindAllString(section, -1)
+	for _, resource := range resourceRegexp.FindAllString(section, -1) {
+		add(resource)
+	}
+	for _, re := range []*regexp.Regexp{hclBlockRegexp, resourceAddressRegexp} {
+		for _, match := range re.FindAllStringSubmatch(body, -1) {
+			add(match[1])
+		}
 	}
 
-	return []string{}
+	return resources
 }
 
 func ComputeLabels(resources []string, regexpLabels []RegexpLabel) []string {
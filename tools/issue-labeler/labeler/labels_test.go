@@ -25,6 +25,14 @@ func TestExtractAffectedResources(t *testing.T) {
 			body:              "<!--- Please keep this note for the community --->\r\n\r\n### Community Note\r\n\r\n* Please vote on this issue by adding a 👍 [reaction](https://blog.github.com/2016-03-10-add-reactions-to-pull-requests-issues-and-comments/) to the original issue to help the community and maintainers prioritize this request\r\n* Please do not leave \"+1\" or \"me too\" comments, they generate extra noise for issue followers and do not help prioritize the request\r\n* If you are interested in working on this issue or have submitted a pull request, please leave a comment. If the issue is assigned to the \"modular-magician\" user, it is either in the process of being autogenerated, or is planned to be autogenerated soon. If the issue is assigned to a user, that user is claiming responsibility for the issue. If the issue is assigned to \"hashibot\", a community member has claimed the issue already.\r\n\r\n<!--- Thank you for keeping this note for the community --->\r\n\r\n### Description\r\n\r\n<!--- Please leave a helpful description of the feature request here. Including use cases and why it would help you is a great way to convince maintainers to spend time on it. --->\r\n\r\nSupport for creating mute configs in SCC:\r\nhttps://cloud.google.com/security-command-center/docs/reference/rest/v1/organizations.muteConfigs/create\r\n\r\n### New or Affected Resource(s)\r\n\r\n<!--- Please list the new or affected resources and data sources. Use google_* if all resources or data sources are affected. --->\r\n\r\n* google_scc_mute_config\r\n\r\n### Potential Terraform Configuration\r\n\r\n<!--- Information about code formatting: https://help.github.com/articles/basic-writing-and-formatting-syntax/#quoting-code --->\r\n\r\n```tf\r\nresource \"google_scc_mute_config\" \"my_config\" {\r\n  config_id    = \"my-config\"\r\n  organisation = \"12345678\"\r\n  description = \"My Awesome Mute Config\"\r\n  filter = \"severity=LOW\"\r\n}\r\n```\r\n\r\nCurious as to why the current notification config is only supported at the org level? Even though the parent config can exist at folder or project level? (Same applies here)\r\n\r\n### References\r\n\r\n<!---\r\nInformation about referencing Github Issues: https://help.github.com/articles/basic-writing-and-formatting-syntax/#referencing-issues-and-pull-requests\r\n\r\nAre there any other GitHub issues (open or closed) or pull requests that should be linked here? Vendor blog posts or documentation?\r\n--->\r\n\r\n* #0000\r\n\r\n<!---\r\nNote Google Cloud customers who are working with a dedicated Technical Account Manager / Customer Engineer: to expedite the investigation and resolution of this issue, please refer to these instructions: https://github.com/hashicorp/terraform-provider-google/wiki/Customer-Contact#raising-gcp-internal-issues-with-the-provider-development-team\r\n--->\r\n",
 			expectedResources: []string{"google_scc_mute_config"},
 		},
+		"resources in HCL and error messages": {
+			body:              "### Affected Resource(s)\r\n\r\n* google_compute_instance\r\n\r\n### Terraform Configuration\r\n\r\n```tf\r\ndata \"google_compute_network\" \"default\" {\r\n  name = \"default\"\r\n}\r\n\r\nresource \"google_compute_instance\" \"default\" {\r\n  network = data.google_compute_network.default.id\r\n  service_account = google_service_account.sa.email\r\n}\r\n```\r\n\r\n### Debug Output\r\n\r\n```\r\nError: Error creating Disk: googleapi: Error 400: Invalid value\r\n\r\n  with google_compute_disk.boot,\r\n  on main.tf line 12, in resource \"google_compute_disk\" \"boot\":\r\n```\r\n",
+			expectedResources: []string{"google_compute_instance", "google_compute_network", "google_compute_disk", "google_service_account"},
+		},
+		"resources in HCL without an affected resources section": {
+			body:              "### Description\r\n\r\n```hcl\r\nresource \"google_pubsub_topic\" \"topic\" {}\r\n```\r\n<!-- resource \"google_storage_bucket\" \"bucket\" {} -->\r\n",
+			expectedResources: []string{"google_pubsub_topic"},
+		},
 		"no resources returns empty slice": {
 			body:              "### New or Affected Resource(s):\r\n#",
 			expectedResources: []string{},
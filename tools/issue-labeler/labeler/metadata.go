@@ -0,0 +1,98 @@
+package labeler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ResourceMetadata is the subset of a generated provider's resource metadata
+// (resource_*_meta.yaml) that is needed to label issues.
+type ResourceMetadata struct {
+	Resource       string `yaml:"resource"`
+	ApiServiceName string `yaml:"api_service_name"`
+	// Product is the name of the services/ package containing the resource.
+	Product string `yaml:"-"`
+}
+
+// LoadResourceMetadata reads the metadata of every resource in the provider
+// repository checked out at providerPath.
+func LoadResourceMetadata(providerPath string) ([]ResourceMetadata, error) {
+	paths, err := filepath.Glob(filepath.Join(providerPath, "google*", "services", "*", "*_meta.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("listing metadata files: %w", err)
+	}
+	var metadata []ResourceMetadata
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		var m ResourceMetadata
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("unmarshalling %s: %w", path, err)
+		}
+		if m.Resource == "" {
+			continue
+		}
+		m.Product = filepath.Base(filepath.Dir(path))
+		metadata = append(metadata, m)
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].Resource < metadata[j].Resource
+	})
+	return metadata, nil
+}
+
+// ServiceLabel returns the service label in enrolledTeams that matches a resource's
+// API service name or, if there is none, its product. It returns "" if neither
+// has a label, since labels that aren't in enrolled_teams.yml don't exist.
+func (m ResourceMetadata) ServiceLabel(enrolledTeams map[string]LabelData) string {
+	service, _, _ := strings.Cut(m.ApiServiceName, ".")
+	for _, name := range []string{service, m.Product} {
+		if name == "" {
+			continue
+		}
+		if _, ok := enrolledTeams["service/"+name]; ok {
+			return "service/" + name
+		}
+	}
+	return ""
+}
+
+// BuildLabels returns the labels for resources in teamsYaml followed by the labels
+// derived from metadata. Since ComputeLabels applies the first matching label, the
+// entries in teamsYaml override the derived labels.
+func BuildLabels(teamsYaml []byte, metadata []ResourceMetadata) ([]RegexpLabel, error) {
+	regexpLabels, err := BuildRegexLabels(teamsYaml)
+	if err != nil {
+		return regexpLabels, err
+	}
+	enrolledTeams := make(map[string]LabelData)
+	if err := yaml.Unmarshal(teamsYaml, &enrolledTeams); err != nil {
+		return regexpLabels, fmt.Errorf("unmarshalling enrolled teams yaml: %w", err)
+	}
+	return append(regexpLabels, MetadataLabels(enrolledTeams, metadata)...), nil
+}
+
+// MetadataLabels returns an exact-match label for every resource in metadata
+// whose service has a label in enrolledTeams.
+func MetadataLabels(enrolledTeams map[string]LabelData, metadata []ResourceMetadata) []RegexpLabel {
+	regexpLabels := []RegexpLabel{}
+	for _, m := range metadata {
+		label := m.ServiceLabel(enrolledTeams)
+		if label == "" {
+			continue
+		}
+		regexpLabels = append(regexpLabels, RegexpLabel{
+			Regexp: regexp.MustCompile(fmt.Sprintf("^%s$", regexp.QuoteMeta(m.Resource))),
+			Label:  label,
+		})
+	}
+	return regexpLabels
+}
@@ -0,0 +1,129 @@
+package labeler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestLoadResourceMetadata(t *testing.T) {
+	providerPath := t.TempDir()
+	files := map[string]string{
+		"google-beta/services/sql/resource_sql_user_meta.yaml": `resource: 'google_sql_user'
+generation_type: 'handwritten'
+api_service_name: 'sqladmin.googleapis.com'
+api_version: 'v1beta4'
+api_resource_type_kind: 'User'
+`,
+		"google-beta/services/alloydb/resource_alloydb_cluster_generated_meta.yaml": `resource: 'google_alloydb_cluster'
+generation_type: 'mmv1'
+api_service_name: 'alloydb.googleapis.com'
+`,
+		"google-beta/services/alloydb/resource_alloydb_cluster.go": "package alloydb",
+	}
+	for name, content := range files {
+		path := filepath.Join(providerPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metadata, err := LoadResourceMetadata(providerPath)
+	if err != nil {
+		t.Fatalf("LoadResourceMetadata() returned error: %v", err)
+	}
+	want := []ResourceMetadata{
+		{Resource: "google_alloydb_cluster", ApiServiceName: "alloydb.googleapis.com", Product: "alloydb"},
+		{Resource: "google_sql_user", ApiServiceName: "sqladmin.googleapis.com", Product: "sql"},
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("want %v; got %v", want, metadata)
+	}
+}
+
+func TestServiceLabel(t *testing.T) {
+	enrolledTeams := map[string]LabelData{
+		"service/sqladmin": {},
+		"service/alloydb":  {},
+	}
+	cases := map[string]struct {
+		metadata      ResourceMetadata
+		expectedLabel string
+	}{
+		"api service name": {
+			metadata:      ResourceMetadata{Resource: "google_sql_user", ApiServiceName: "sqladmin.googleapis.com", Product: "sql"},
+			expectedLabel: "service/sqladmin",
+		},
+		"product without api service name": {
+			metadata:      ResourceMetadata{Resource: "google_alloydb_cluster", Product: "alloydb"},
+			expectedLabel: "service/alloydb",
+		},
+		"product when api service name has no label": {
+			metadata:      ResourceMetadata{Resource: "google_alloydb_cluster", ApiServiceName: "alloydbadmin.googleapis.com", Product: "alloydb"},
+			expectedLabel: "service/alloydb",
+		},
+		"no enrolled label": {
+			metadata:      ResourceMetadata{Resource: "google_compute_address", ApiServiceName: "compute.googleapis.com", Product: "compute"},
+			expectedLabel: "",
+		},
+		"no service": {
+			metadata:      ResourceMetadata{Resource: "google_sql_user"},
+			expectedLabel: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if label := tc.metadata.ServiceLabel(enrolledTeams); label != tc.expectedLabel {
+				t.Errorf("want %q; got %q", tc.expectedLabel, label)
+			}
+		})
+	}
+}
+
+func TestBuildLabels(t *testing.T) {
+	yaml := []byte(`
+service/sqladmin-cp:
+  resources:
+  - google_sql_database_instance
+service/sqladmin:
+  resources: []`)
+	metadata := []ResourceMetadata{
+		{Resource: "google_sql_database_instance", ApiServiceName: "sqladmin.googleapis.com"},
+		{Resource: "google_sql_user", ApiServiceName: "sqladmin.googleapis.com"},
+		{Resource: "google_compute_address", ApiServiceName: "compute.googleapis.com", Product: "compute"},
+	}
+	regexpLabels, err := BuildLabels(yaml, metadata)
+	if err != nil {
+		t.Fatalf("BuildLabels() returned error: %v", err)
+	}
+	want := []RegexpLabel{
+		{Regexp: regexp.MustCompile("^google_sql_database_instance$"), Label: "service/sqladmin-cp"},
+		{Regexp: regexp.MustCompile("^google_sql_database_instance$"), Label: "service/sqladmin"},
+		{Regexp: regexp.MustCompile("^google_sql_user$"), Label: "service/sqladmin"},
+	}
+	if !reflect.DeepEqual(regexpLabels, want) {
+		t.Errorf("want %v; got %v", want, regexpLabels)
+	}
+
+	labels := ComputeLabels([]string{"google_sql_database_instance"}, regexpLabels)
+	if want := []string{"service/sqladmin-cp"}; !slices.Equal(labels, want) {
+		t.Errorf("enrolled_teams.yml should override metadata labels: want %v; got %v", want, labels)
+	}
+	labels = ComputeLabels([]string{"google_sql_user"}, regexpLabels)
+	if want := []string{"service/sqladmin"}; !slices.Equal(labels, want) {
+		t.Errorf("want %v; got %v", want, labels)
+	}
+	if labels := ComputeLabels([]string{"google_compute_address"}, regexpLabels); len(labels) != 0 {
+		t.Errorf("labels that aren't in enrolled_teams.yml should not be applied: got %v", labels)
+	}
+}
@@ -0,0 +1,178 @@
+package labeler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ResourceMatch records which regexp matched a resource and the label it applied.
+type ResourceMatch struct {
+	Resource string `json:"resource"`
+	Regexp   string `json:"regexp"`
+	Label    string `json:"label"`
+}
+
+// IssueReport compares an issue's current labels with the labels computed for it.
+type IssueReport struct {
+	Number                uint64          `json:"number"`
+	CurrentLabels         []string        `json:"current_labels"`
+	ComputedLabels        []string        `json:"computed_labels"`
+	MissingLabels         []string        `json:"missing_labels"`
+	Matches               []ResourceMatch `json:"matches"`
+	UnrecognisedResources []string        `json:"unrecognised_resources"`
+}
+
+// CoverageReport lists the provider's resources that no label regexp matches.
+type CoverageReport struct {
+	TotalResources     int      `json:"total_resources"`
+	MatchedResources   int      `json:"matched_resources"`
+	UnmatchedResources []string `json:"unmatched_resources"`
+}
+
+// MatchResource returns the first regexp label that matches resource, as used by ComputeLabels.
+func MatchResource(resource string, regexpLabels []RegexpLabel) (RegexpLabel, bool) {
+	for _, rl := range regexpLabels {
+		if rl.Regexp.MatchString(resource) {
+			return rl, true
+		}
+	}
+	return RegexpLabel{}, false
+}
+
+// ComputeIssueReports reports the current labels of every issue that is not a pull request,
+// along with the labels ComputeIssueUpdates would set on it.
+func ComputeIssueReports(issues []Issue, regexpLabels []RegexpLabel) []IssueReport {
+	updates := make(map[uint64]IssueUpdate)
+	for _, update := range ComputeIssueUpdates(issues, regexpLabels) {
+		updates[update.Number] = update
+	}
+
+	reports := []IssueReport{}
+	for _, issue := range issues {
+		if len(issue.PullRequest) > 0 {
+			continue
+		}
+		report := IssueReport{
+			Number:                issue.Number,
+			CurrentLabels:         []string{},
+			MissingLabels:         []string{},
+			Matches:               []ResourceMatch{},
+			UnrecognisedResources: []string{},
+		}
+		for _, label := range issue.Labels {
+			report.CurrentLabels = append(report.CurrentLabels, label.Name)
+		}
+		sort.Strings(report.CurrentLabels)
+
+		for _, resource := range ExtractAffectedResources(issue.Body) {
+			rl, ok := MatchResource(resource, regexpLabels)
+			if !ok {
+				report.UnrecognisedResources = append(report.UnrecognisedResources, resource)
+				continue
+			}
+			report.Matches = append(report.Matches, ResourceMatch{
+				Resource: resource,
+				Regexp:   rl.Regexp.String(),
+				Label:    rl.Label,
+			})
+		}
+
+		// Issues without an update keep their current labels.
+		report.ComputedLabels = report.CurrentLabels
+		if update, ok := updates[issue.Number]; ok {
+			report.ComputedLabels = update.Labels
+		}
+		for _, label := range report.ComputedLabels {
+			if !slices.Contains(report.CurrentLabels, label) {
+				report.MissingLabels = append(report.MissingLabels, label)
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// ComputeCoverage reports which of the given resources are not matched by any regexp label.
+func ComputeCoverage(resources []string, regexpLabels []RegexpLabel) CoverageReport {
+	coverage := CoverageReport{UnmatchedResources: []string{}}
+	seen := make(map[string]struct{})
+	for _, resource := range resources {
+		if _, ok := seen[resource]; ok {
+			continue
+		}
+		seen[resource] = struct{}{}
+		coverage.TotalResources++
+		if _, ok := MatchResource(resource, regexpLabels); ok {
+			coverage.MatchedResources++
+		} else {
+			coverage.UnmatchedResources = append(coverage.UnmatchedResources, resource)
+		}
+	}
+	sort.Strings(coverage.UnmatchedResources)
+	return coverage
+}
+
+// WriteIssueReports writes reports to w in the given format, either "csv" or "json".
+func WriteIssueReports(w io.Writer, reports []IssueReport, format string) error {
+	switch format {
+	case "json":
+		return writeJSON(w, reports)
+	case "csv":
+		rows := [][]string{{"number", "current_labels", "computed_labels", "missing_labels", "matches", "unrecognised_resources"}}
+		for _, r := range reports {
+			var matches []string
+			for _, m := range r.Matches {
+				matches = append(matches, fmt.Sprintf("%s=%s (%s)", m.Resource, m.Label, m.Regexp))
+			}
+			rows = append(rows, []string{
+				strconv.FormatUint(r.Number, 10),
+				strings.Join(r.CurrentLabels, ";"),
+				strings.Join(r.ComputedLabels, ";"),
+				strings.Join(r.MissingLabels, ";"),
+				strings.Join(matches, ";"),
+				strings.Join(r.UnrecognisedResources, ";"),
+			})
+		}
+		return writeCSV(w, rows)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// WriteCoverage writes coverage to w in the given format, either "csv" or "json".
+func WriteCoverage(w io.Writer, coverage CoverageReport, format string) error {
+	switch format {
+	case "json":
+		return writeJSON(w, coverage)
+	case "csv":
+		rows := [][]string{{"unmatched_resource"}}
+		for _, resource := range coverage.UnmatchedResources {
+			rows = append(rows, []string{resource})
+		}
+		return writeCSV(w, rows)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encoding json: %w", err)
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("writing csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package labeler
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestComputeIssueReports(t *testing.T) {
+	regexpLabels := []RegexpLabel{
+		{
+			Regexp: regexp.MustCompile("^google_service1_.*$"),
+			Label:  "service/service1",
+		},
+		{
+			Regexp: regexp.MustCompile("^google_service2_resource1$"),
+			Label:  "service/service2",
+		},
+	}
+	issues := []Issue{
+		{
+			Number: 1,
+			Body:   testIssueBodyWithResources([]string{"google_service1_resource1", "google_service2_resource1", "google_unknown"}),
+			Labels: []Label{{Name: "service/service2"}, {Name: "bug"}},
+		},
+		{
+			Number:      2,
+			Body:        testIssueBodyWithResources([]string{"google_service1_resource1"}),
+			PullRequest: map[string]any{"url": "https://github.com/hashicorp/terraform-provider-google/pull/2"},
+		},
+		{
+			Number: 3,
+			Body:   testIssueBodyWithResources([]string{"google_service1_resource1"}),
+			Labels: []Label{{Name: "service/terraform"}},
+		},
+		{
+			Number: 4,
+			Body:   testIssueBodyWithResources([]string{"google_service1_resource1"}),
+			Labels: []Label{{Name: "forward/linked"}, {Name: "service/service2"}},
+		},
+		{
+			Number: 5,
+			Body:   testIssueBodyWithResources([]string{"google_service1_resource1"}),
+			Labels: []Label{{Name: "forward/exempt"}},
+		},
+		{
+			Number: 6,
+			Body:   testIssueBodyWithResources([]string{"google_service1_resource1"}),
+			Labels: []Label{{Name: "service/service1"}},
+		},
+	}
+	service1Match := []ResourceMatch{
+		{Resource: "google_service1_resource1", Regexp: "^google_service1_.*$", Label: "service/service1"},
+	}
+	want := []IssueReport{
+		{
+			Number:         1,
+			CurrentLabels:  []string{"bug", "service/service2"},
+			ComputedLabels: []string{"bug", "forward/review", "service/service1", "service/service2"},
+			MissingLabels:  []string{"forward/review", "service/service1"},
+			Matches: []ResourceMatch{
+				{Resource: "google_service1_resource1", Regexp: "^google_service1_.*$", Label: "service/service1"},
+				{Resource: "google_service2_resource1", Regexp: "^google_service2_resource1$", Label: "service/service2"},
+			},
+			UnrecognisedResources: []string{"google_unknown"},
+		},
+		{
+			Number:                3,
+			CurrentLabels:         []string{"service/terraform"},
+			ComputedLabels:        []string{"service/terraform"},
+			MissingLabels:         []string{},
+			Matches:               service1Match,
+			UnrecognisedResources: []string{},
+		},
+		{
+			Number:                4,
+			CurrentLabels:         []string{"forward/linked", "service/service2"},
+			ComputedLabels:        []string{"forward/linked", "service/service2"},
+			MissingLabels:         []string{},
+			Matches:               service1Match,
+			UnrecognisedResources: []string{},
+		},
+		{
+			Number:                5,
+			CurrentLabels:         []string{"forward/exempt"},
+			ComputedLabels:        []string{"forward/exempt"},
+			MissingLabels:         []string{},
+			Matches:               service1Match,
+			UnrecognisedResources: []string{},
+		},
+		{
+			Number:                6,
+			CurrentLabels:         []string{"service/service1"},
+			ComputedLabels:        []string{"service/service1"},
+			MissingLabels:         []string{},
+			Matches:               service1Match,
+			UnrecognisedResources: []string{},
+		},
+	}
+	if got := ComputeIssueReports(issues, regexpLabels); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+}
+
+func TestComputeCoverage(t *testing.T) {
+	regexpLabels := []RegexpLabel{
+		{
+			Regexp: regexp.MustCompile("^google_service1_.*$"),
+			Label:  "service/service1",
+		},
+	}
+	resources := []string{"google_service2_resource1", "google_service1_resource1", "google_service1_resource1", "google_resource3"}
+	want := CoverageReport{
+		TotalResources:     3,
+		MatchedResources:   1,
+		UnmatchedResources: []string{"google_resource3", "google_service2_resource1"},
+	}
+	if got := ComputeCoverage(resources, regexpLabels); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v; got %+v", want, got)
+	}
+}
+
+func TestWriteIssueReports(t *testing.T) {
+	reports := []IssueReport{
+		{
+			Number:         1,
+			CurrentLabels:  []string{"bug"},
+			ComputedLabels: []string{"service/service1"},
+			MissingLabels:  []string{"service/service1"},
+			Matches: []ResourceMatch{
+				{Resource: "google_service1_resource1", Regexp: "^google_service1_.*$", Label: "service/service1"},
+			},
+			UnrecognisedResources: []string{"google_unknown", "google_other"},
+		},
+	}
+	cases := map[string]struct {
+		format   string
+		expected string
+	}{
+		"csv": {
+			format: "csv",
+			expected: "number,current_labels,computed_labels,missing_labels,matches,unrecognised_resources\n" +
+				"1,bug,service/service1,service/service1,google_service1_resource1=service/service1 (^google_service1_.*$),google_unknown;google_other\n",
+		},
+		"json": {
+			format:   "json",
+			expected: `"unrecognised_resources": [` + "\n      \"google_unknown\",\n      \"google_other\"\n    ]",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			sb := new(strings.Builder)
+			if err := WriteIssueReports(sb, reports, tc.format); err != nil {
+				t.Fatalf("WriteIssueReports() returned error: %v", err)
+			}
+			if !strings.Contains(sb.String(), tc.expected) {
+				t.Errorf("want output containing %q; got %q", tc.expected, sb.String())
+			}
+		})
+	}
+
+	if err := WriteIssueReports(new(strings.Builder), reports, "xml"); err == nil {
+		t.Error("want error for unknown format; got nil")
+	}
+}
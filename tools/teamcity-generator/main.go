@@ -44,7 +44,7 @@ func main() {
 		log.Fatalf("invalid version flag value: value must be `%s` or `%s`", GA_VERSION, BETA_VERSION)
 	}
 
-	log.Printf("Generating TeamCity configuration service package map for `%s` provider", terraformResourceDirectory)
+	log.Printf("Generating TeamCity configuration service package map and configuration for `%s` provider", terraformResourceDirectory)
 
 	// Get a list of the service packages found in a given directory
 	servicesDir := fmt.Sprintf("%s/%s/services", outputPath, terraformResourceDirectory)
@@ -77,9 +77,33 @@ func main() {
 		log.Fatalf("error writing to file `.teamcity/components/generated/services.kt` in output directory: %s", err)
 	}
 
+	// Derive the test configuration of each service package from its source, and save it to
+	// .teamcity/components/inputs/service_config_<version>.kt, replacing the empty map copied from magic-modules
+	envvarDir := fmt.Sprintf("%s/%s/envvar", outputPath, terraformResourceDirectory)
+	configs, err := readServiceConfigs(servicesDir, envvarDir, serviceList)
+	if err != nil {
+		log.Fatalf("error deriving service package configuration: %s", err)
+	}
+	configMap, err := createServiceConfigMap(configs, serviceConfigMapName(version))
+	if err != nil {
+		log.Fatalf("error creating service package configuration map: %s", err)
+	}
+	serviceConfigKtFilePath := fmt.Sprintf("%s/.teamcity/components/inputs/service_config_%s.kt", outputPath, version)
+	log.Printf("Saving service package configuration to %s", serviceConfigKtFilePath)
+	if err := os.WriteFile(serviceConfigKtFilePath, []byte(configMap), 0644); err != nil {
+		log.Fatalf("error writing to file `%s`: %s", serviceConfigKtFilePath, err)
+	}
+
 	log.Println("Finished")
 }
 
+func serviceConfigMapName(version string) string {
+	if version == BETA_VERSION {
+		return "ServiceConfigBeta"
+	}
+	return "ServiceConfigGa"
+}
+
 func readAllServicePackages(providerDir string) ([]string, error) {
 	packages, err := os.ReadDir(providerDir)
 	if err != nil {
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// These match the defaults in .teamcity/components/constants.kt
+const defaultParallelism = 6
+const defaultBuildTimeoutMinutes = 60 * 12
+
+// serviceConfig is the test configuration of a service package, derived from its source.
+type serviceConfig struct {
+	Name string
+	// Parallelism is the number of acceptance tests in the package that can run in parallel,
+	// capped at defaultParallelism.
+	Parallelism int
+	// BuildTimeoutMinutes is long enough to create, update and delete the slowest resource in
+	// the package, and at least defaultBuildTimeoutMinutes.
+	BuildTimeoutMinutes int
+	// EnvironmentVariables are the variables read by the package's tests through the envvar package.
+	EnvironmentVariables []string
+	// Sweepers are the names of the sweepers registered by the package.
+	Sweepers []string
+	// SweeperDependencies are the other service packages registering sweepers that the package's
+	// sweepers depend on, and that therefore run whenever the package's sweepers do.
+	SweeperDependencies []string
+}
+
+// packageSource holds the facts about a package's source that its serviceConfig is derived from.
+type packageSource struct {
+	parallelTests        int
+	maxTimeouts          map[string]int
+	environmentVariables map[string]struct{}
+	sweepers             []string
+	sweeperDependencies  map[string]struct{}
+}
+
+// readServiceConfigs derives the test configuration of each service package in servicesDir.
+// envvarDir is the provider's envvar package, used to resolve the environment variables read by
+// tests.
+func readServiceConfigs(servicesDir, envvarDir string, services []string) ([]serviceConfig, error) {
+	envVarFuncs, err := readEnvVarFuncs(envvarDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading envvar package: %w", err)
+	}
+
+	sources := make(map[string]*packageSource, len(services))
+	// sweeperServices maps the name of each sweeper to the package registering it
+	sweeperServices := make(map[string]string)
+	for _, s := range services {
+		src, err := readPackageSource(filepath.Join(servicesDir, s), envVarFuncs)
+		if err != nil {
+			return nil, fmt.Errorf("error reading service package %s: %w", s, err)
+		}
+		sources[s] = src
+		for _, name := range src.sweepers {
+			sweeperServices[name] = s
+		}
+	}
+
+	configs := make([]serviceConfig, 0, len(services))
+	for _, s := range services {
+		src := sources[s]
+		c := serviceConfig{
+			Name:                 s,
+			Parallelism:          defaultParallelism,
+			BuildTimeoutMinutes:  defaultBuildTimeoutMinutes,
+			EnvironmentVariables: sortedKeys(src.environmentVariables),
+			Sweepers:             src.sweepers,
+		}
+		deps := make(map[string]struct{})
+		for name := range src.sweeperDependencies {
+			if dep, ok := sweeperServices[name]; ok && dep != s {
+				deps[dep] = struct{}{}
+			}
+		}
+		c.SweeperDependencies = sortedKeys(deps)
+		if src.parallelTests < defaultParallelism {
+			c.Parallelism = int(math.Max(1, float64(src.parallelTests)))
+		}
+		// Round the time to create, update and delete the slowest resource up to the hour.
+		slowest := src.maxTimeouts["Create"] + src.maxTimeouts["Update"] + src.maxTimeouts["Delete"]
+		if slowest = (slowest + 59) / 60 * 60; slowest > c.BuildTimeoutMinutes {
+			c.BuildTimeoutMinutes = slowest
+		}
+		configs = append(configs, c)
+	}
+	return configs, nil
+}
+
+// readEnvVarFuncs returns the environment variables read by each function in the envvar package.
+// Functions read the variables in package-level string slices, like `OrgEnvVars`, and the first
+// element of each slice is the variable that is set in TeamCity.
+func readEnvVarFuncs(envvarDir string) (map[string][]string, error) {
+	files, err := parseDir(envvarDir, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make(map[string]string)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i >= len(vs.Values) {
+						continue
+					}
+					if lit, ok := vs.Values[i].(*ast.CompositeLit); ok && len(lit.Elts) > 0 {
+						if v, ok := stringLit(lit.Elts[0]); ok {
+							lists[name.Name] = v
+						}
+					}
+				}
+			}
+		}
+	}
+
+	funcs := make(map[string][]string)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil {
+				continue
+			}
+			vars := make(map[string]struct{})
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok {
+					if v, ok := lists[id.Name]; ok {
+						vars[v] = struct{}{}
+					}
+				}
+				return true
+			})
+			if len(vars) > 0 {
+				funcs[fn.Name.Name] = sortedKeys(vars)
+			}
+		}
+	}
+	return funcs, nil
+}
+
+func readPackageSource(dir string, envVarFuncs map[string][]string) (*packageSource, error) {
+	files, err := parseDir(dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &packageSource{
+		maxTimeouts:          make(map[string]int),
+		environmentVariables: make(map[string]struct{}),
+		sweepers:             []string{},
+		sweeperDependencies:  make(map[string]struct{}),
+	}
+	for name, f := range files {
+		isTest := strings.HasSuffix(name, "_test.go")
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				if isTest && strings.HasPrefix(n.Name.Name, "TestAcc") && n.Body != nil && callsParallel(n.Body) {
+					src.parallelTests++
+				}
+			case *ast.CallExpr:
+				pkg, fn := selector(n.Fun)
+				switch {
+				case isTest && pkg == "envvar":
+					for _, v := range envVarFuncs[fn] {
+						src.environmentVariables[v] = struct{}{}
+					}
+				case !isTest && pkg == "sweeper" && (fn == "AddTestSweepers" || fn == "AddTestSweepersWithOptions") && len(n.Args) > 0:
+					if name, ok := stringLit(n.Args[0]); ok {
+						src.sweepers = append(src.sweepers, name)
+					}
+					if fn == "AddTestSweepersWithOptions" && len(n.Args) > 2 {
+						for _, dep := range sweeperDependencies(n.Args[2]) {
+							src.sweeperDependencies[dep] = struct{}{}
+						}
+					}
+				}
+			case *ast.KeyValueExpr:
+				key, ok := n.Key.(*ast.Ident)
+				if !ok || (key.Name != "Create" && key.Name != "Update" && key.Name != "Delete") {
+					return true
+				}
+				if minutes, ok := defaultTimeoutMinutes(n.Value); ok && minutes > src.maxTimeouts[key.Name] {
+					src.maxTimeouts[key.Name] = minutes
+				}
+			}
+			return true
+		})
+	}
+	sort.Strings(src.sweepers)
+	return src, nil
+}
+
+// parseDir parses the Go files in dir, keyed by file name.
+func parseDir(dir string, includeTests bool) (map[string]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := make(map[string]*ast.File)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || (!includeTests && strings.HasSuffix(name, "_test.go")) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = f
+	}
+	return files, nil
+}
+
+// callsParallel reports whether body calls t.Parallel().
+func callsParallel(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if _, fn := selector(call.Fun); fn == "Parallel" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// sweeperDependencies returns the names in the Dependencies field of an expression like
+// `sweeper.SweeperOptions{Dependencies: []string{"ComputeNetwork"}}`.
+func sweeperDependencies(expr ast.Expr) []string {
+	opts, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, elt := range opts.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "Dependencies" {
+			continue
+		}
+		deps, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		for _, d := range deps.Elts {
+			if name, ok := stringLit(d); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// defaultTimeoutMinutes returns the minutes in an expression like
+// `schema.DefaultTimeout(20 * time.Minute)`.
+func defaultTimeoutMinutes(expr ast.Expr) (int, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return 0, false
+	}
+	if _, fn := selector(call.Fun); fn != "DefaultTimeout" {
+		return 0, false
+	}
+	mul, ok := call.Args[0].(*ast.BinaryExpr)
+	if !ok || mul.Op != token.MUL {
+		return 0, false
+	}
+	lit, ok := mul.X.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	n, err := strconv.Atoi(lit.Value)
+	if err != nil {
+		return 0, false
+	}
+	switch _, unit := selector(mul.Y); unit {
+	case "Minute":
+		return n, true
+	case "Hour":
+		return n * 60, true
+	}
+	return 0, false
+}
+
+// selector returns the package and name of an expression like `pkg.Name`.
+func selector(expr ast.Expr) (string, string) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+	if x, ok := sel.X.(*ast.Ident); ok {
+		return x.Name, sel.Sel.Name
+	}
+	return "", sel.Sel.Name
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// createServiceConfigMap returns a Kotlin file declaring a map of service package names to their
+// ServiceConfig, named mapName.
+func createServiceConfigMap(configs []serviceConfig, mapName string) (string, error) {
+	var b strings.Builder
+	// Add copyright header
+	b.WriteString("/*\n")
+	b.WriteString(" * Copyright (c) HashiCorp, Inc.\n")
+	b.WriteString(" * SPDX-License-Identifier: MPL-2.0\n")
+	b.WriteString(" */\n\n")
+
+	// Add autogen notice
+	b.WriteString("// this file is auto-generated by magic-modules/tools/teamcity-generator, any changes made here will be overwritten\n\n")
+
+	b.WriteString("package generated\n\n")
+	fmt.Fprintf(&b, "var %s = mapOf(\n", mapName)
+	for i, c := range configs {
+		_, err := fmt.Fprintf(&b, `    "%s" to ServiceConfig(
+        parallelism = %d,
+        buildTimeout = %d,
+        environmentVariables = %s,
+        sweepers = %s,
+        sweeperDependencies = %s
+    )`, c.Name, c.Parallelism, c.BuildTimeoutMinutes, kotlinList(c.EnvironmentVariables), kotlinList(c.Sweepers), kotlinList(c.SweeperDependencies))
+		if err != nil {
+			return "", err
+		}
+		// Final entry in map doesn't have comma
+		if i < len(configs)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(")\n")
+
+	return b.String(), nil
+}
+
+func kotlinList(values []string) string {
+	if len(values) == 0 {
+		return "listOf<String>()"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return fmt.Sprintf("listOf(%s)", strings.Join(quoted, ", "))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadServiceConfigs(t *testing.T) {
+	services, err := readAllServicePackages("testdata/google/services")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs, err := readServiceConfigs("testdata/google/services", "testdata/google/envvar", services)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []serviceConfig{
+		{
+			Name:                 "alpha",
+			Parallelism:          2,
+			BuildTimeoutMinutes:  900,
+			EnvironmentVariables: []string{"GOOGLE_ORG", "GOOGLE_PROJECT"},
+			Sweepers:             []string{"AlphaWidget"},
+			SweeperDependencies:  []string{},
+		},
+		{
+			Name:                 "beta",
+			Parallelism:          1,
+			BuildTimeoutMinutes:  defaultBuildTimeoutMinutes,
+			EnvironmentVariables: []string{"GOOGLE_BILLING_ACCOUNT", "GOOGLE_ORG"},
+			Sweepers:             []string{"BetaGadget"},
+			SweeperDependencies:  []string{"alpha"},
+		},
+	}
+	if !reflect.DeepEqual(configs, expected) {
+		t.Errorf("expected configs %+v, got %+v", expected, configs)
+	}
+}
+
+func TestCreateServiceConfigMap(t *testing.T) {
+	configs := []serviceConfig{
+		{
+			Name:                 "alpha",
+			Parallelism:          2,
+			BuildTimeoutMinutes:  900,
+			EnvironmentVariables: []string{"GOOGLE_ORG"},
+			Sweepers:             []string{"AlphaWidget"},
+		},
+		{
+			Name:                "beta",
+			Parallelism:         6,
+			BuildTimeoutMinutes: 720,
+			SweeperDependencies: []string{"alpha"},
+		},
+	}
+	got, err := createServiceConfigMap(configs, "ServiceConfigGa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `/*
+ * Copyright (c) HashiCorp, Inc.
+ * SPDX-License-Identifier: MPL-2.0
+ */
+
+// this file is auto-generated by magic-modules/tools/teamcity-generator, any changes made here will be overwritten
+
+package generated
+
+var ServiceConfigGa = mapOf(
+    "alpha" to ServiceConfig(
+        parallelism = 2,
+        buildTimeout = 900,
+        environmentVariables = listOf("GOOGLE_ORG"),
+        sweepers = listOf("AlphaWidget"),
+        sweeperDependencies = listOf<String>()
+    ),
+    "beta" to ServiceConfig(
+        parallelism = 6,
+        buildTimeout = 720,
+        environmentVariables = listOf<String>(),
+        sweepers = listOf<String>(),
+        sweeperDependencies = listOf("alpha")
+    )
+)
+`
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,28 @@
+package envvar
+
+var OrgEnvVars = []string{
+	"GOOGLE_ORG",
+}
+
+var BillingAccountEnvVars = []string{
+	"GOOGLE_BILLING_ACCOUNT",
+}
+
+var ProjectEnvVars = []string{
+	"GOOGLE_PROJECT",
+	"GCLOUD_PROJECT",
+}
+
+func GetTestOrgFromEnv(t *testing.T) string {
+	SkipIfEnvNotSet(t, OrgEnvVars...)
+	return transport_tpg.MultiEnvSearch(OrgEnvVars)
+}
+
+func GetTestBillingAccountFromEnv(t *testing.T) string {
+	SkipIfEnvNotSet(t, BillingAccountEnvVars...)
+	return transport_tpg.MultiEnvSearch(BillingAccountEnvVars)
+}
+
+func GetTestProjectFromEnv() string {
+	return transport_tpg.MultiEnvSearch(ProjectEnvVars)
+}
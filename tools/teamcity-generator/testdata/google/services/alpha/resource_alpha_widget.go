@@ -0,0 +1,11 @@
+package alpha
+
+func ResourceAlphaWidget() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(6 * time.Hour),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(8 * time.Hour),
+		},
+	}
+}
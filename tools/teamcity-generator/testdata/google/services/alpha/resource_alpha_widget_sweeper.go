@@ -0,0 +1,5 @@
+package alpha
+
+func init() {
+	sweeper.AddTestSweepers("AlphaWidget", testSweepAlphaWidget)
+}
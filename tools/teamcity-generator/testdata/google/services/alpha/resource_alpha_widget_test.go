@@ -0,0 +1,19 @@
+package alpha_test
+
+import (
+	"github.com/hashicorp/terraform-provider-google/google/envvar"
+)
+
+func TestAccAlphaWidget_basic(t *testing.T) {
+	t.Parallel()
+
+	context := map[string]interface{}{
+		"org_id": envvar.GetTestOrgFromEnv(t),
+	}
+}
+
+func TestAccAlphaWidget_update(t *testing.T) {
+	t.Parallel()
+
+	project := envvar.GetTestProjectFromEnv()
+}
@@ -0,0 +1,12 @@
+package beta
+
+func init() {
+	sweeper.AddTestSweepersWithOptions("BetaGadget", testSweepBetaGadget, sweeper.SweeperOptions{
+		// Sweepers of resources that must be deleted before this one
+		Dependencies: []string{
+			"AlphaWidget",
+			"BetaGadgetPart",
+		},
+		SupportsDryRun: true,
+	})
+}
@@ -0,0 +1,15 @@
+package beta_test
+
+import (
+	"github.com/hashicorp/terraform-provider-google/google/envvar"
+)
+
+func TestAccBetaGadget_basic(t *testing.T) {
+	t.Parallel()
+
+	billingAccount := envvar.GetTestBillingAccountFromEnv(t)
+}
+
+func TestAccBetaGadget_serial(t *testing.T) {
+	org := envvar.GetTestOrgFromEnv(t)
+}
@@ -1,3 +1,15 @@
 module github.com/GoogleCloudPlatform/magic-modules/tools/template-check
 
-go 1.23
+go 1.23.0
+
+replace github.com/GoogleCloudPlatform/magic-modules/mmv1 => ../../mmv1
+
+require github.com/GoogleCloudPlatform/magic-modules/mmv1 v0.0.0-00010101000000-000000000000
+
+require (
+	github.com/golang/glog v1.2.0 // indirect
+	github.com/otiai10/copy v1.9.0 // indirect
+	golang.org/x/exp v0.0.0-20240222234643-814bf88cf225 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+)
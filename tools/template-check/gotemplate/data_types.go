@@ -0,0 +1,62 @@
+package gotemplate
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
+)
+
+// Templates that are executed with a property rather than a resource, relative to templates/terraform/.
+var propertyTemplates = map[string]bool{
+	"schema_property.go.tmpl":                          true,
+	"schema_subresource.go.tmpl":                       true,
+	"flatten_property_method.go.tmpl":                  true,
+	"expand_property_method.go.tmpl":                   true,
+	"property_documentation.html.markdown.tmpl":        true,
+	"nested_property_documentation.html.markdown.tmpl": true,
+	"unordered_list_customize_diff.go.tmpl":            true,
+}
+
+// Templates that are executed with a map built by the dict function, so the fields they access
+// can't be checked.
+var dictTemplates = map[string]bool{
+	"expand_resource_ref.tmpl":                  true,
+	"env_var_context.go.tmpl":                   true,
+	"custom_flatten/bigquery_table_ref.go.tmpl": true,
+}
+
+// DataType returns the type that the mmv1 template at path is executed with, or nil if it is not
+// known. path may be absolute or relative, and is matched on its templates/terraform/ or
+// templates/tgc/ suffix.
+func DataType(path string) reflect.Type {
+	path = filepath.ToSlash(path)
+	if strings.Contains(path, "templates/tgc/") {
+		return reflect.TypeOf(api.Resource{})
+	}
+	i := strings.LastIndex(path, "templates/terraform/")
+	if i < 0 {
+		return nil
+	}
+	rel := path[i+len("templates/terraform/"):]
+	switch {
+	case dictTemplates[rel]:
+		return nil
+	case propertyTemplates[rel],
+		strings.HasPrefix(rel, "custom_flatten/"),
+		strings.HasPrefix(rel, "custom_expand/"):
+		return reflect.TypeOf(&api.Type{})
+	case rel == "examples/base_configs/test_file.go.tmpl":
+		return reflect.TypeOf(provider.TestInput{})
+	case rel == "mock_server.go.tmpl":
+		return reflect.TypeOf(provider.MockServerInput{})
+	case rel == "examples/base_configs/iam_test_file.go.tmpl":
+		return reflect.TypeOf(api.Resource{})
+	case strings.HasPrefix(rel, "examples/"):
+		return reflect.TypeOf(&resource.Examples{})
+	}
+	return reflect.TypeOf(&api.Resource{})
+}
@@ -0,0 +1,241 @@
+package gotemplate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// Finding is a problem found in a file, reported as file:line.
+type Finding struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s", f.File, f.Line, f.Message)
+}
+
+// CheckTemplate parses a template and returns a finding for every syntax error, such as an
+// unbalanced {{ if }} / {{ end }}. If root is not nil, field and method accesses on the
+// template's data are also checked against root, the type the template is executed with.
+func CheckTemplate(filename, text string, root reflect.Type) []Finding {
+	trees := make(map[string]*parse.Tree)
+	t := parse.New(filename)
+	t.Mode = parse.SkipFuncCheck
+	if _, err := t.Parse(text, "", "", trees); err != nil {
+		return []Finding{parseErrorFinding(filename, err)}
+	}
+	if root == nil {
+		return nil
+	}
+
+	var findings []Finding
+	names := make([]string, 0, len(trees))
+	for name := range trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := &checker{filename: filename, text: text}
+		c.walk(trees[name].Root, root, map[string]reflect.Type{"$": root})
+		findings = append(findings, c.findings...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+	return findings
+}
+
+// parseErrorFinding converts errors of the form "template: name:line: message" to a finding.
+func parseErrorFinding(filename string, err error) Finding {
+	msg := strings.TrimPrefix(err.Error(), "template: ")
+	line := 0
+	if rest, ok := strings.CutPrefix(msg, filename+":"); ok {
+		if n, err := fmt.Sscanf(rest, "%d:", &line); n == 1 && err == nil {
+			msg = strings.TrimSpace(rest[strings.Index(rest, ":")+1:])
+		}
+	}
+	return Finding{File: filename, Line: line, Message: msg}
+}
+
+type checker struct {
+	filename string
+	text     string
+	findings []Finding
+}
+
+func (c *checker) errorf(node parse.Node, format string, args ...any) {
+	line := 1 + strings.Count(c.text[:int(node.Position())], "\n")
+	c.findings = append(c.findings, Finding{File: c.filename, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// walk checks node, where dot is the type of "." (nil if unknown) and vars holds the known
+// types of variables in scope.
+func (c *checker) walk(node parse.Node, dot reflect.Type, vars map[string]reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(child, dot, vars)
+		}
+	case *parse.ActionNode:
+		c.pipe(n.Pipe, dot, vars)
+	case *parse.IfNode:
+		c.pipe(n.Pipe, dot, vars)
+		c.walk(n.List, dot, scope(vars))
+		c.walk(n.ElseList, dot, scope(vars))
+	case *parse.WithNode:
+		t := c.pipe(n.Pipe, dot, vars)
+		c.walk(n.List, t, scope(vars))
+		c.walk(n.ElseList, dot, scope(vars))
+	case *parse.RangeNode:
+		inner := scope(vars)
+		elem := elemType(c.pipeType(n.Pipe, dot, vars))
+		c.pipe(n.Pipe, dot, vars)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner[n.Pipe.Decl[0].Ident[0]] = nil
+			inner[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		c.walk(n.List, elem, inner)
+		c.walk(n.ElseList, dot, scope(vars))
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			c.pipe(n.Pipe, dot, vars)
+		}
+	}
+}
+
+// pipe checks a pipeline, declares its variables and returns its type.
+func (c *checker) pipe(pipe *parse.PipeNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			c.arg(arg, dot, vars)
+		}
+	}
+	t := c.pipeType(pipe, dot, vars)
+	for _, v := range pipe.Decl {
+		if pipe.IsAssign {
+			// Assignments may change the type of an existing variable.
+			vars[v.Ident[0]] = nil
+		} else {
+			vars[v.Ident[0]] = t
+		}
+	}
+	return t
+}
+
+func (c *checker) arg(node parse.Node, dot reflect.Type, vars map[string]reflect.Type) {
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		if _, err := resolve(dot, n.Ident); err != nil {
+			c.errorf(n, "%s: %s", n, err)
+		}
+	case *parse.VariableNode:
+		// Undefined variables are reported by the parser.
+		if _, err := resolve(vars[n.Ident[0]], n.Ident[1:]); err != nil {
+			c.errorf(n, "%s: %s", n, err)
+		}
+	case *parse.PipeNode:
+		c.pipe(n, dot, scope(vars))
+	case *parse.ChainNode:
+		c.arg(n.Node, dot, vars)
+	}
+}
+
+// pipeType returns the type of a pipeline consisting of a single field or variable, or nil if
+// the type can't be determined.
+func (c *checker) pipeType(pipe *parse.PipeNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) == 0 {
+		return nil
+	}
+	var t reflect.Type
+	switch n := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		t, _ = resolve(dot, n.Ident)
+	case *parse.VariableNode:
+		t, _ = resolve(vars[n.Ident[0]], n.Ident[1:])
+	case *parse.DotNode:
+		t = dot
+	}
+	return t
+}
+
+// resolve returns the type of accessing idents in order on a value of type t. It returns a nil
+// type if the result can't be determined statically, and an error if an access is invalid.
+func resolve(t reflect.Type, idents []string) (reflect.Type, error) {
+	for _, ident := range idents {
+		if t == nil {
+			return nil, nil
+		}
+		if m, ok := method(t, ident); ok {
+			if m.Type.NumOut() == 0 {
+				return nil, nil
+			}
+			t = m.Type.Out(0)
+			continue
+		}
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Interface:
+			return nil, nil
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			f, ok := t.FieldByName(ident)
+			if !ok || !f.IsExported() {
+				return nil, fmt.Errorf("can't evaluate field %s in type %s", ident, t)
+			}
+			t = f.Type
+		default:
+			return nil, fmt.Errorf("can't evaluate field %s in type %s", ident, t)
+		}
+	}
+	return t, nil
+}
+
+// method looks up an exported method on t or a pointer to t.
+func method(t reflect.Type, name string) (reflect.Method, bool) {
+	if m, ok := t.MethodByName(name); ok {
+		return m, true
+	}
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		return reflect.PointerTo(t).MethodByName(name)
+	}
+	return reflect.Method{}, false
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+	}
+	return nil
+}
+
+func scope(vars map[string]reflect.Type) map[string]reflect.Type {
+	inner := make(map[string]reflect.Type, len(vars))
+	for k, v := range vars {
+		inner[k] = v
+	}
+	return inner
+}
@@ -0,0 +1,114 @@
+package gotemplate
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testProperty struct {
+	Name     string
+	Required bool
+}
+
+type testResource struct {
+	Name       string
+	Properties []*testProperty
+	Labels     map[string]string
+	Extra      any
+}
+
+func (r testResource) TitlelizeName() string {
+	return r.Name
+}
+
+func (r *testResource) FirstProperty() *testProperty {
+	return r.Properties[0]
+}
+
+func TestCheckTemplate(t *testing.T) {
+	cases := map[string]struct {
+		fileText         string
+		root             reflect.Type
+		expectedFindings []string
+	}{
+		"valid fields and methods": {
+			fileText: "{{ $.Name }}\n{{ .TitlelizeName }}\n{{ $.FirstProperty.Required }}\n{{ $.Labels.foo }}\n{{ $.Extra.Anything }}",
+			root:     reflect.TypeOf(testResource{}),
+		},
+		"unknown field": {
+			fileText:         "some text\n{{ $.Nmae }}",
+			root:             reflect.TypeOf(testResource{}),
+			expectedFindings: []string{"test.tmpl:2: $.Nmae: can't evaluate field Nmae in type gotemplate.testResource"},
+		},
+		"unknown field in range": {
+			fileText:         "{{ range $prop := $.Properties }}\n{{ $prop.Name }}\n{{ .Requried }}\n{{ end }}",
+			root:             reflect.TypeOf(&testResource{}),
+			expectedFindings: []string{"test.tmpl:3: .Requried: can't evaluate field Requried in type gotemplate.testProperty"},
+		},
+		"unknown field in with": {
+			fileText:         "{{ with $.FirstProperty }}{{ .Nmae }}{{ end }}",
+			root:             reflect.TypeOf(&testResource{}),
+			expectedFindings: []string{"test.tmpl:1: .Nmae: can't evaluate field Nmae in type gotemplate.testProperty"},
+		},
+		"field on a variable": {
+			fileText:         "{{ $p := $.FirstProperty }}\n{{ $p.Required.Foo }}",
+			root:             reflect.TypeOf(&testResource{}),
+			expectedFindings: []string{"test.tmpl:2: $p.Required.Foo: can't evaluate field Foo in type bool"},
+		},
+		"unknown types are not checked": {
+			fileText: "{{ $x := index $.Properties 0 }}{{ $x.Anything }}{{ range $.Properties }}{{ $x = 1 }}{{ end }}",
+			root:     reflect.TypeOf(&testResource{}),
+		},
+		"unbalanced if": {
+			fileText:         "{{ if $.Name }}\nsome text\n",
+			root:             reflect.TypeOf(testResource{}),
+			expectedFindings: []string{"test.tmpl:3: unexpected EOF"},
+		},
+		"unbalanced end": {
+			fileText:         "some text\n{{ end }}",
+			expectedFindings: []string{"test.tmpl:2: unexpected {{end}}"},
+		},
+		"no root type": {
+			fileText: "{{ $.Anything }}",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			findings := CheckTemplate("test.tmpl", tc.fileText, tc.root)
+			if len(findings) != len(tc.expectedFindings) {
+				t.Fatalf("Expected findings %v, got %v", tc.expectedFindings, findings)
+			}
+			for i, finding := range findings {
+				if finding.String() != tc.expectedFindings[i] {
+					t.Errorf("Expected finding %s, got %s", tc.expectedFindings[i], finding)
+				}
+			}
+		})
+	}
+}
+
+func TestDataType(t *testing.T) {
+	cases := map[string]string{
+		"mmv1/templates/terraform/resource.go.tmpl":                        "*api.Resource",
+		"../../mmv1/templates/terraform/pre_create/foo.go.tmpl":            "*api.Resource",
+		"mmv1/templates/terraform/custom_flatten/foo.go.tmpl":              "*api.Type",
+		"mmv1/templates/terraform/schema_property.go.tmpl":                 "*api.Type",
+		"mmv1/templates/terraform/examples/foo.tf.tmpl":                    "*resource.Examples",
+		"mmv1/templates/terraform/examples/base_configs/test_file.go.tmpl": "provider.TestInput",
+		"mmv1/templates/terraform/mock_server.go.tmpl":                     "provider.MockServerInput",
+		"mmv1/templates/tgc/resource_converter.go.tmpl":                    "api.Resource",
+	}
+	for path, want := range cases {
+		if got := DataType(path); got == nil || got.String() != want {
+			t.Errorf("DataType(%q) = %v, want %s", path, got, want)
+		}
+	}
+	for _, path := range []string{"mmv1/templates/terraform/expand_resource_ref.tmpl", "tpgtools/templates/resource.go.tmpl"} {
+		if got := DataType(path); got != nil {
+			t.Errorf("DataType(%q) = %v, want nil", path, got)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package gotemplate
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var templateReferenceRegexp = regexp.MustCompile(`templates/terraform/[\w/.-]*\w`)
+
+// Custom code templates in these directories are referenced by a path derived from the resource,
+// rather than by name, so they are never reported as unused.
+var derivedTemplateDirs = []string{
+	"templates/terraform/examples",
+	"templates/terraform/state_migrations",
+}
+
+// CheckTemplateReferences checks the templates referenced by the mmv1 directory at mmv1Dir. It
+// returns a finding for every reference in products/ to a template that does not exist, and for
+// every template in a subdirectory of templates/terraform/ that is not referenced in products/,
+// in a template or in Go code.
+func CheckTemplateReferences(mmv1Dir string) ([]Finding, error) {
+	var findings []Finding
+	referenced := make(map[string]bool)
+	err := filepath.WalkDir(mmv1Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "third_party" || d.Name() == "build" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".yaml" && ext != ".go" && ext != ".tmpl" {
+			return nil
+		}
+		rel, err := filepath.Rel(mmv1Dir, path)
+		if err != nil {
+			return err
+		}
+		inProducts := strings.HasPrefix(filepath.ToSlash(rel), "products/")
+		return scanReferences(path, func(line int, ref string) {
+			referenced[ref] = true
+			if !inProducts {
+				return
+			}
+			if _, err := os.Stat(filepath.Join(mmv1Dir, ref)); err != nil {
+				findings = append(findings, Finding{File: path, Line: line, Message: fmt.Sprintf("referenced template %s does not exist", ref)})
+			}
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	templatesDir := filepath.Join(mmv1Dir, "templates", "terraform")
+	err = filepath.WalkDir(templatesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".tmpl" || filepath.Dir(path) == templatesDir {
+			return err
+		}
+		rel, err := filepath.Rel(mmv1Dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !referenced[rel] && !isDerivedTemplate(rel) {
+			findings = append(findings, Finding{File: path, Line: 1, Message: "template is not referenced"})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+// scanReferences calls found with the line number of every template path referenced in a file.
+func scanReferences(path string, found func(line int, ref string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	lineNum := 1
+	for scanner.Scan() {
+		for _, ref := range templateReferenceRegexp.FindAllString(scanner.Text(), -1) {
+			found(lineNum, ref)
+		}
+		lineNum++
+	}
+	return scanner.Err()
+}
+
+func isDerivedTemplate(rel string) bool {
+	for _, dir := range derivedTemplateDirs {
+		if strings.HasPrefix(rel, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
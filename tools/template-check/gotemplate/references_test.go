@@ -0,0 +1,47 @@
+package gotemplate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckTemplateReferences(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"products/foo/Bar.yaml":                            "custom_code:\n  pre_create: 'templates/terraform/pre_create/bar.go.tmpl'\n  post_create: 'templates/terraform/post_create/missing.go.tmpl'\n",
+		"provider/terraform.go":                            `"templates/terraform/iam/iam_context.go.tmpl"`,
+		"templates/terraform/resource.go.tmpl":             "",
+		"templates/terraform/pre_create/bar.go.tmpl":       "",
+		"templates/terraform/pre_delete/unused.go.tmpl":    "",
+		"templates/terraform/iam/iam_context.go.tmpl":      "",
+		"templates/terraform/examples/example.tf.tmpl":     "",
+		"templates/terraform/state_migrations/foo.go.tmpl": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings, err := CheckTemplateReferences(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		filepath.Join(dir, "products/foo/Bar.yaml") + ":3: referenced template templates/terraform/post_create/missing.go.tmpl does not exist",
+		filepath.Join(dir, "templates/terraform/pre_delete/unused.go.tmpl") + ":1: template is not referenced",
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected findings %v, got %v", expected, findings)
+	}
+	for i, finding := range findings {
+		if finding.String() != expected[i] {
+			t.Errorf("Expected finding %s, got %s", expected[i], finding)
+		}
+	}
+}
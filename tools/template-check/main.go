@@ -26,22 +26,65 @@ func isValidTemplate(filename string) (bool, error) {
 	return true, nil
 }
 
+// lintTemplate reports syntax errors in the template, and field accesses that are invalid for the
+// type the template is executed with.
+func lintTemplate(filename string) (bool, error) {
+	text, err := os.ReadFile(filename)
+	if err != nil {
+		return false, err
+	}
+	findings := gotemplate.CheckTemplate(filename, string(text), gotemplate.DataType(filename))
+	printFindings(findings)
+	return len(findings) == 0, nil
+}
+
+func printFindings(findings []gotemplate.Finding) {
+	for _, finding := range findings {
+		fmt.Fprintf(os.Stderr, "error: %s\n", finding)
+	}
+}
+
 func checkTemplate(filename string) bool {
-	valid, err := isValidTemplate(filename)
+	valid := true
+	for _, check := range []func(string) (bool, error){isValidTemplate, lintTemplate} {
+		ok, err := check(filename)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return false
+		}
+		valid = valid && ok
+	}
+	return valid
+}
+
+func checkReferences(mmv1Dir string) bool {
+	findings, err := gotemplate.CheckTemplateReferences(mmv1Dir)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		return false
 	}
-	return valid
+	printFindings(findings)
+	return len(findings) == 0
 }
 
 func main() {
 	flag.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), "template-check - check that a template file is valid\n    template-check [file]\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "template-check - check that a template file is valid\n    template-check [-mmv1 dir] [file]\n")
+		flag.PrintDefaults()
 	}
+	mmv1Dir := flag.String("mmv1", "", "check that the custom code templates referenced in this mmv1 directory exist and are used")
 
 	flag.Parse()
 
+	if *mmv1Dir != "" {
+		if !checkReferences(*mmv1Dir) {
+			os.Exit(1)
+		}
+		if flag.Arg(0) == "" {
+			os.Exit(0)
+		}
+	}
+
 	// Handle file as a positional argument
 	if flag.Arg(0) != "" {
 		if !checkTemplate(flag.Arg(0)) {
@@ -966,9 +966,9 @@ func createPropertiesFromSchema(schema *openapi.Schema, typeFetcher *TypeFetcher
 			p.StateGetter = nil
 
 			props = append(props, build_effective_labels_field(p, resource, parent))
+			props = append(props, build_terraform_labels_field(p, resource, parent))
 
 			if p.IsResourceLabels() {
-				props = append(props, build_terraform_labels_field(p, resource, parent))
 				p.ForceNew = false
 			}
 		}
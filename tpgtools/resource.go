@@ -883,7 +883,7 @@ func (r *Resource) loadHandWrittenSamples() []Sample {
 		}
 
 		if r.HasAnnotations() {
-			sample.IgnoreRead = append(sample.IgnoreRead, "annotations")
+			sample.IgnoreRead = append(sample.IgnoreRead, "annotations", "terraform_annotations")
 		}
 
 		samples = append(samples, sample)
@@ -981,7 +981,7 @@ func (r *Resource) loadDCLSamples() []Sample {
 		}
 
 		if r.HasAnnotations() {
-			sample.IgnoreRead = append(sample.IgnoreRead, "annotations")
+			sample.IgnoreRead = append(sample.IgnoreRead, "annotations", "terraform_annotations")
 		}
 
 		if r.GenerateLongFormTests {